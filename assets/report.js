@@ -0,0 +1,189 @@
+(function () {
+  "use strict";
+
+  var data = JSON.parse(document.getElementById("report-data").textContent);
+  var report = data.report;
+  var palette = ["#0969da", "#1a7f37", "#bf3989", "#9a6700", "#8250df", "#cf222e", "#0550ae", "#116329", "#953800", "#6e7781"];
+  var SVG_NS = "http://www.w3.org/2000/svg";
+
+  function el(name, attrs, parent) {
+    var node = document.createElementNS(SVG_NS, name);
+    Object.keys(attrs || {}).forEach(function (key) { node.setAttribute(key, attrs[key]); });
+    if (parent) { parent.appendChild(node); }
+    return node;
+  }
+
+  function text(parent, x, y, value, anchor) {
+    var node = el("text", { x: x, y: y, "text-anchor": anchor || "middle" }, parent);
+    node.textContent = value;
+    return node;
+  }
+
+  function empty(container, message) {
+    var p = document.createElement("p");
+    p.className = "empty";
+    p.textContent = message;
+    container.appendChild(p);
+  }
+
+  // start_date é o início do período no fuso do relatório (RFC3339); a data local são os 10 primeiros
+  // caracteres, e convertê-la com Date mudaria o dia em fusos diferentes de UTC
+  function weekLabel(week) {
+    var date = week.start_date.slice(0, 10).split("-");
+    return date[2] + "/" + date[1];
+  }
+
+  function colorFor(users) {
+    var colors = {};
+    users.forEach(function (user, i) { colors[user] = palette[i % palette.length]; });
+    return colors;
+  }
+
+  function legend(container, users, colors) {
+    var div = document.createElement("div");
+    div.className = "legend";
+    users.forEach(function (user) {
+      var span = document.createElement("span");
+      var swatch = document.createElement("i");
+      swatch.style.background = colors[user];
+      span.appendChild(swatch);
+      span.appendChild(document.createTextNode(user));
+      div.appendChild(span);
+    });
+    container.appendChild(div);
+  }
+
+  function usersIn(field) {
+    var seen = {};
+    report.weeks.forEach(function (week) {
+      Object.keys(week[field] || {}).forEach(function (user) { seen[user] = true; });
+    });
+    return Object.keys(seen).sort();
+  }
+
+  // Gráfico de barras agrupadas: uma série por usuário em cada semana
+  function groupedBars(container, field, emptyMessage) {
+    var users = usersIn(field);
+    if (report.weeks.length === 0 || users.length === 0) {
+      empty(container, emptyMessage);
+      return;
+    }
+
+    var colors = colorFor(users);
+    var max = 0;
+    report.weeks.forEach(function (week) {
+      users.forEach(function (user) { max = Math.max(max, (week[field] || {})[user] || 0); });
+    });
+    max = max || 1;
+
+    var barWidth = 10;
+    var groupWidth = users.length * barWidth + 16;
+    var height = 200;
+    var padding = 24;
+    var width = Math.max(420, padding * 2 + report.weeks.length * groupWidth);
+    var svg = el("svg", { width: width, height: height + padding * 2 }, container);
+
+    el("line", { x1: padding, y1: height + padding, x2: width - padding, y2: height + padding, "class": "axis" }, svg);
+    text(svg, padding - 4, padding + 4, max.toFixed(max % 1 === 0 ? 0 : 1), "end");
+
+    report.weeks.forEach(function (week, w) {
+      var x0 = padding + w * groupWidth + 8;
+      users.forEach(function (user, u) {
+        var value = (week[field] || {})[user] || 0;
+        var h = Math.max(0, value) / max * height;
+        var rect = el("rect", {
+          x: x0 + u * barWidth,
+          y: height + padding - h,
+          width: barWidth - 2,
+          height: h,
+          fill: colors[user]
+        }, svg);
+        el("title", {}, rect).textContent = user + ": " + value;
+      });
+      text(svg, x0 + (users.length * barWidth) / 2, height + padding + 16, weekLabel(week));
+    });
+
+    legend(container, users, colors);
+  }
+
+  // Gráfico de linhas com a pontuação acumulada de cada usuário
+  function cumulativeLine(container) {
+    var series = data.cumulative || {};
+    var users = Object.keys(series).sort();
+    if (report.weeks.length === 0 || users.length === 0) {
+      empty(container, "Nenhuma pontuação registrada no período.");
+      return;
+    }
+
+    var colors = colorFor(users);
+    var max = 1;
+    users.forEach(function (user) {
+      series[user].forEach(function (value) { max = Math.max(max, value); });
+    });
+
+    var height = 200;
+    var padding = 24;
+    var step = Math.max(48, 380 / Math.max(1, report.weeks.length - 1));
+    var width = Math.max(420, padding * 2 + step * (report.weeks.length - 1) + 16);
+    var svg = el("svg", { width: width, height: height + padding * 2 }, container);
+
+    el("line", { x1: padding, y1: height + padding, x2: width - padding, y2: height + padding, "class": "axis" }, svg);
+    text(svg, padding - 4, padding + 4, String(max), "end");
+
+    report.weeks.forEach(function (week, w) {
+      text(svg, padding + 8 + w * step, height + padding + 16, weekLabel(week));
+    });
+
+    users.forEach(function (user) {
+      var points = series[user].map(function (value, w) {
+        return (padding + 8 + w * step) + "," + (height + padding - value / max * height);
+      });
+      el("polyline", { points: points.join(" "), fill: "none", stroke: colors[user], "stroke-width": 2 }, svg);
+    });
+
+    legend(container, users, colors);
+  }
+
+  // Tabela com os PRs de cada usuário por repositório
+  function repoBreakdown(container) {
+    var totals = {};
+    report.users.forEach(function (user) {
+      Object.keys(user.repo_stats || {}).forEach(function (repo) {
+        totals[repo] = totals[repo] || {};
+        totals[repo][user.username] = user.repo_stats[repo];
+      });
+    });
+
+    var repos = Object.keys(totals).sort();
+    if (repos.length === 0) {
+      empty(container, "Nenhum dado por repositório disponível.");
+      return;
+    }
+
+    var table = document.createElement("table");
+    table.innerHTML = "<thead><tr><th>Repositório</th><th>Usuário</th><th class=\"num\">PRs</th></tr></thead>";
+    var tbody = document.createElement("tbody");
+    repos.forEach(function (repo) {
+      var users = Object.keys(totals[repo]).sort(function (a, b) {
+        return totals[repo][b] - totals[repo][a] || a.localeCompare(b);
+      });
+      users.forEach(function (user, i) {
+        var tr = document.createElement("tr");
+        [i === 0 ? repo : "", user, String(totals[repo][user])].forEach(function (value, c) {
+          var td = document.createElement("td");
+          if (c === 2) { td.className = "num"; }
+          td.textContent = value;
+          tr.appendChild(td);
+        });
+        tbody.appendChild(tr);
+      });
+    });
+    table.appendChild(tbody);
+    container.appendChild(table);
+  }
+
+  groupedBars(document.getElementById("weekly-prs"), "user_prs", "Nenhum PR mergeado no período.");
+  groupedBars(document.getElementById("weekly-weighted"), "user_weighted_comments", "Nenhum comentário pontuado no período.");
+  cumulativeLine(document.getElementById("cumulative-score"));
+  repoBreakdown(document.getElementById("repo-breakdown"));
+})();
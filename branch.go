@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// DefaultBranchKeyword na lista de branches de produção é resolvida para a branch padrão do repositório
+const DefaultBranchKeyword = "default"
+
+// regexBranchPrefix marca uma expressão regular na lista de branches (ex: re:^release/v\d+$)
+const regexBranchPrefix = "re:"
+
+// BranchMatcher decide se a branch de destino de um PR é uma branch de produção.
+// Cada padrão pode ser um nome literal, um glob (release/*) ou uma regex com o prefixo re:.
+type BranchMatcher struct {
+	patterns []func(branch string) bool
+}
+
+// isGlobPattern indica se o padrão usa curingas de glob
+func isGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// usesDefaultBranch indica se a lista de branches depende da branch padrão do repositório
+func usesDefaultBranch(patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == DefaultBranchKeyword {
+			return true
+		}
+	}
+	return false
+}
+
+// splitBranchPatterns separa a lista de branches de produção pelo separador |. Um item re: vai até o
+// fim da lista, para que a regex possa usar alternância (ex: main|re:^(release|hotfix)/)
+func splitBranchPatterns(value string) []string {
+	var patterns []string
+	rest := value
+	for rest != "" {
+		item := rest
+		rest = ""
+		if !strings.HasPrefix(strings.TrimSpace(item), regexBranchPrefix) {
+			item, rest, _ = strings.Cut(item, "|")
+		}
+		if item = strings.TrimSpace(item); item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return patterns
+}
+
+// validateBranchPatterns verifica os padrões sem resolver a branch padrão
+func validateBranchPatterns(patterns []string) error {
+	_, err := NewBranchMatcher(patterns, DefaultBranchKeyword)
+	return err
+}
+
+// NewBranchMatcher compila os padrões; defaultBranch substitui a palavra-chave default
+func NewBranchMatcher(patterns []string, defaultBranch string) (*BranchMatcher, error) {
+	matcher := &BranchMatcher{}
+
+	for _, pattern := range patterns {
+		switch {
+		case pattern == DefaultBranchKeyword:
+			if defaultBranch == "" {
+				return nil, fmt.Errorf("branch padrão do repositório não resolvida para o padrão %q", pattern)
+			}
+			literal := defaultBranch
+			matcher.patterns = append(matcher.patterns, func(branch string) bool { return branch == literal })
+
+		case strings.HasPrefix(pattern, regexBranchPrefix):
+			re, err := regexp.Compile(strings.TrimPrefix(pattern, regexBranchPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("regex de branch inválida %q: %v", pattern, err)
+			}
+			matcher.patterns = append(matcher.patterns, re.MatchString)
+
+		case isGlobPattern(pattern):
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("padrão de branch inválido %q: %v", pattern, err)
+			}
+			glob := pattern
+			matcher.patterns = append(matcher.patterns, func(branch string) bool {
+				matched, _ := path.Match(glob, branch)
+				return matched
+			})
+
+		default:
+			literal := pattern
+			matcher.patterns = append(matcher.patterns, func(branch string) bool { return branch == literal })
+		}
+	}
+
+	return matcher, nil
+}
+
+// Match indica se a branch casa com algum dos padrões
+func (m *BranchMatcher) Match(branch string) bool {
+	for _, matches := range m.patterns {
+		if matches(branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// branchMatcher compila as branches de produção do repositório, resolvendo default pela API quando usado
+func (pc *PRChampion) branchMatcher(repo Repository, productionBranches []string) (*BranchMatcher, error) {
+	defaultBranch := ""
+	if usesDefaultBranch(productionBranches) {
+		resolver, ok := pc.client.(infrastructure.DefaultBranchResolver)
+		if !ok {
+			return nil, fmt.Errorf("o cliente atual não resolve a branch padrão de %s/%s", repo.Owner, repo.Name)
+		}
+
+		var err error
+		defaultBranch, err = resolver.GetDefaultBranch(context.Background(), repo.Owner, repo.Name)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(pc.progress(), "    🌿 Branch padrão de %s/%s: %s\n", repo.Owner, repo.Name, defaultBranch)
+	}
+
+	return NewBranchMatcher(productionBranches, defaultBranch)
+}
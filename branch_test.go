@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+func TestBranchMatcher(t *testing.T) {
+	matcher, err := NewBranchMatcher([]string{"main", "release/*", `re:^hotfix/\d+$`, DefaultBranchKeyword}, "trunk")
+	if err != nil {
+		t.Fatalf("NewBranchMatcher() error = %v", err)
+	}
+
+	tests := []struct {
+		branch   string
+		expected bool
+	}{
+		{"main", true},
+		{"trunk", true},
+		{"release/1.2", true},
+		{"release/1.2/rc", false}, // * não atravessa '/'
+		{"hotfix/42", true},
+		{"hotfix/urgent", false},
+		{"feature/release/1", false},
+		{"default", false}, // a palavra-chave é resolvida, não comparada literalmente
+	}
+
+	for _, tt := range tests {
+		if got := matcher.Match(tt.branch); got != tt.expected {
+			t.Errorf("Match(%q) = %v, expected %v", tt.branch, got, tt.expected)
+		}
+	}
+}
+
+func TestBranchMatcherValidation(t *testing.T) {
+	if _, err := NewBranchMatcher([]string{"re:release/("}, ""); err == nil {
+		t.Error("Expected error for invalid regex")
+	}
+	if _, err := NewBranchMatcher([]string{"release/["}, ""); err == nil {
+		t.Error("Expected error for invalid glob")
+	}
+	if _, err := NewBranchMatcher([]string{DefaultBranchKeyword}, ""); err == nil {
+		t.Error("Expected error for unresolved default branch")
+	}
+
+	if _, err := parseRepositories([]string{"org/api:re:release/("}); err == nil {
+		t.Error("Expected parseRepositories to reject invalid branch regex")
+	}
+	if _, err := parseRepositories([]string{"org/api:default|release/*"}); err != nil {
+		t.Errorf("Unexpected error for valid patterns: %v", err)
+	}
+}
+
+func TestSplitBranchPatternsKeepsRegexAlternation(t *testing.T) {
+	tests := map[string][]string{
+		"main|release/*":                    {"main", "release/*"},
+		"main | re:^(release|hotfix)/":      {"main", "re:^(release|hotfix)/"},
+		"re:^(main|master)$":                {"re:^(main|master)$"},
+		"default||re:^v\\d+$|^hotfix/\\d+$": {"default", "re:^v\\d+$|^hotfix/\\d+$"},
+	}
+	for input, expected := range tests {
+		if got := splitBranchPatterns(input); !reflect.DeepEqual(got, expected) {
+			t.Errorf("splitBranchPatterns(%q) = %v, want %v", input, got, expected)
+		}
+	}
+
+	repositories, err := parseRepositories([]string{"org/api:main|re:^(release|hotfix)/"})
+	if err != nil {
+		t.Fatalf("parseRepositories() error = %v", err)
+	}
+	matcher, err := NewBranchMatcher(repositories[0].ProductionBranches, "")
+	if err != nil {
+		t.Fatalf("NewBranchMatcher() error = %v", err)
+	}
+	for branch, expected := range map[string]bool{"main": true, "release/1.0": true, "hotfix/2": true, "feature/x": false} {
+		if matcher.Match(branch) != expected {
+			t.Errorf("Match(%q) = %v, want %v", branch, !expected, expected)
+		}
+	}
+}
+
+// fakeBranchClient retorna PRs fixos e resolve a branch padrão do repositório
+type fakeBranchClient struct {
+	fakeCommentsClient
+	prs                  []*github.PullRequest
+	defaultBranch        string
+	defaultBranchLookups int
+}
+
+func (f *fakeBranchClient) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
+	return f.prs, nil
+}
+
+func (f *fakeBranchClient) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	f.defaultBranchLookups++
+	return f.defaultBranch, nil
+}
+
+func TestFetchMergedPRsMatchesBranchPatterns(t *testing.T) {
+	mergedAt := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	branches := []string{"trunk", "release/2024.10", "hotfix/7", "feature/x"}
+
+	client := &fakeBranchClient{defaultBranch: "trunk"}
+	for i, branch := range branches {
+		pr := newTestPR("org", "api", "alice", i+1, mergedAt)
+		pr.Base.Ref = github.String(branch)
+		client.prs = append(client.prs, pr)
+	}
+
+	pc := &PRChampion{
+		client:       client,
+		repositories: []Repository{{Owner: "org", Name: "api", ProductionBranches: []string{DefaultBranchKeyword, "release/*", `re:^hotfix/\d+$`}}},
+		startDate:    mergedAt.AddDate(0, 0, -7),
+		endDate:      mergedAt.AddDate(0, 0, 7),
+		userStats:    make(map[string]*UserStats),
+	}
+
+	if err := pc.FetchMergedPRs(); err != nil {
+		t.Fatalf("FetchMergedPRs() error = %v", err)
+	}
+
+	if got := pc.userStats["alice"].PRsCount; got != 3 {
+		t.Errorf("Expected 3 PRs on production branches, got %d", got)
+	}
+	if client.defaultBranchLookups != 1 {
+		t.Errorf("Expected one default branch lookup, got %d", client.defaultBranchLookups)
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspeciona e limpa seletivamente o cache de PRs, comentários e reações",
+	Long: `Inspeciona e limpa seletivamente o cache SQLite, sem precisar apagar tudo com --clear-database.
+
+Exemplos:
+  pr-champion cache stats
+  pr-champion cache prune --repo org/api --older-than 90d
+  pr-champion cache invalidate --pr org/api#123
+  pr-champion cache invalidate --pr ghe.corp.com/org/api#123
+  pr-champion cache vacuum`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Mostra linhas por repositório, idade do cache, linhas desatualizadas e tamanho do banco",
+	Run:   runCacheStats,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove PRs mergeados antes do período informado, com seus comentários e reações",
+	Run:   runCachePrune,
+}
+
+var cacheInvalidateCmd = &cobra.Command{
+	Use:   "invalidate",
+	Short: "Descarta comentários e reações de um PR para que sejam buscados novamente",
+	Run:   runCacheInvalidate,
+}
+
+var cacheVacuumCmd = &cobra.Command{
+	Use:   "vacuum",
+	Short: "Compacta o arquivo do banco, liberando o espaço das linhas removidas",
+	Run:   runCacheVacuum,
+}
+
+// openCacheDatabase abre o banco informado em --database, que precisa existir. O banco é aberto sem
+// migrar: algumas migrações descartam dados em cache, o que um comando de inspeção não deve fazer
+func openCacheDatabase(cmd *cobra.Command) (database.CommentDatabase, string) {
+	dbPath, _ := cmd.Flags().GetString("database")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		log.Fatalf("❌ Banco %s não encontrado", dbPath)
+	}
+
+	pending, err := database.PendingMigrations(dbPath)
+	if err != nil {
+		log.Fatalf("❌ Erro ao verificar versão do banco: %v", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("❌ Banco %s tem %d migração(ões) pendente(s) (versão %d em diante); execute 'pr-champion db migrate' antes de usar o comando cache",
+			dbPath, len(pending), pending[0].Version)
+	}
+
+	db, err := database.OpenSQLiteDatabase(dbPath)
+	if err != nil {
+		log.Fatalf("❌ Erro ao abrir banco: %v", err)
+	}
+	return db, dbPath
+}
+
+// runCacheStats exibe o resumo do cache
+func runCacheStats(cmd *cobra.Command, args []string) {
+	cacheTTL, _ := cmd.Flags().GetString("cache-ttl")
+	freezeAfterMerge, _ := cmd.Flags().GetString("freeze-after-merge")
+	refreshCurrentWeek, _ := cmd.Flags().GetBool("refresh-current-week")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	weekStartFlag, _ := cmd.Flags().GetString("week-start")
+
+	// Mesma política do relatório, para contar exatamente o que a próxima execução buscará de novo
+	freshness, err := ParseFreshnessPolicy(cacheTTL, freezeAfterMerge, refreshCurrentWeek)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	calendar, err := NewWeekCalendar(timezone, weekStartFlag)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	now := time.Now()
+	freshness.CurrentWeekStart = calendar.WeekStartOf(now)
+
+	db, dbPath := openCacheDatabase(cmd)
+	defer db.Close()
+
+	stats, err := db.Stats(func(cachedAt, mergedAt time.Time) bool {
+		return freshness.IsStale(cachedAt, mergedAt, now)
+	})
+	if err != nil {
+		log.Fatalf("❌ Erro ao calcular estatísticas do cache: %v", err)
+	}
+
+	fmt.Printf("🗄️  Cache %s: %s (%s livres, recuperáveis com cache vacuum)\n",
+		dbPath, formatBytes(stats.SizeBytes), formatBytes(stats.FreeBytes))
+
+	fmt.Println("\n📦 Linhas por repositório:")
+	if len(stats.Repos) == 0 {
+		fmt.Println("  (cache vazio)")
+	}
+	for _, repo := range stats.Repos {
+		// Repositórios de outras instâncias aparecem qualificados com o host
+		label := repo.RepoOwner + "/" + repo.RepoName
+		if repo.Host != database.DefaultHost {
+			label = repo.Host + "/" + label
+		}
+		fmt.Printf("  • %s: %d PRs, %d comentários (%d desatualizados), %d reações\n",
+			label, repo.PRs, repo.Comments, repo.StaleComments, repo.Reactions)
+		if repo.Sync != nil {
+			fmt.Printf("    🔄 PRs sincronizados de %s até %s\n",
+				repo.Sync.SyncedFrom.Format("02/01/2006"), repo.Sync.SyncedUntil.Format("02/01/2006"))
+		}
+	}
+
+	fmt.Println("\n⏳ Idade do cache:")
+	for _, bucket := range stats.Age {
+		fmt.Printf("  • %-16s %6d PRs %8d comentários\n", bucket.Label+":", bucket.PRs, bucket.Comments)
+	}
+
+	fmt.Printf("\n⚠️  %d comentários serão buscados novamente na próxima execução (%s)\n",
+		stats.StaleComments, freshness)
+}
+
+// runCachePrune remove o histórico antigo de um repositório (ou de todos)
+func runCachePrune(cmd *cobra.Command, args []string) {
+	repoFlag, _ := cmd.Flags().GetString("repo")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+
+	if olderThan == "" {
+		log.Fatal("❌ --older-than é obrigatório (ex: --older-than 90d)")
+	}
+	days, err := parseDayDuration(olderThan)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	var host, owner, name string
+	if repoFlag != "" {
+		host, owner, name, err = parseRepoName(repoFlag)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	}
+
+	db, _ := openCacheDatabase(cmd)
+	defer db.Close()
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	deletion, err := db.PruneCache(host, owner, name, cutoff)
+	if err != nil {
+		log.Fatalf("❌ Erro ao limpar cache: %v", err)
+	}
+
+	scope := "todos os repositórios"
+	if repoFlag != "" {
+		scope = repoFlag
+	}
+	fmt.Printf("🧹 PRs de %s mergeados antes de %s removidos: %d PRs, %d comentários, %d reações\n",
+		scope, cutoff.Format("02/01/2006"), deletion.PRs, deletion.Comments, deletion.Reactions)
+}
+
+// runCacheInvalidate força a nova busca dos comentários e reações de um PR
+func runCacheInvalidate(cmd *cobra.Command, args []string) {
+	prFlag, _ := cmd.Flags().GetString("pr")
+	if prFlag == "" {
+		log.Fatal("❌ --pr é obrigatório (ex: --pr org/api#123)")
+	}
+
+	host, owner, name, number, err := parsePRReference(prFlag)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	db, _ := openCacheDatabase(cmd)
+	defer db.Close()
+
+	deletion, err := db.InvalidatePR(host, owner, name, number)
+	if err != nil {
+		log.Fatalf("❌ Erro ao invalidar PR: %v", err)
+	}
+	if deletion.PRs == 0 {
+		fmt.Printf("ℹ️  PR %s não está no cache\n", prFlag)
+		return
+	}
+	fmt.Printf("♻️  PR %s invalidado: %d comentários e %d reações serão buscados novamente\n",
+		prFlag, deletion.Comments, deletion.Reactions)
+}
+
+// runCacheVacuum compacta o arquivo do banco
+func runCacheVacuum(cmd *cobra.Command, args []string) {
+	db, dbPath := openCacheDatabase(cmd)
+	defer db.Close()
+
+	before := fileSize(dbPath)
+	if err := db.Vacuum(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	fmt.Printf("✅ Banco compactado: %s → %s\n", formatBytes(before), formatBytes(fileSize(dbPath)))
+}
+
+// parseRepoName separa uma referência owner/name ou host/owner/name. Sem host, a referência vale
+// para o repositório em todos os hosts do cache
+func parseRepoName(value string) (string, string, string, error) {
+	invalid := fmt.Errorf("repositório inválido: %q (use owner/name ou host/owner/name)", value)
+
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	host := ""
+	if len(parts) == 3 {
+		host = strings.ToLower(strings.TrimSpace(parts[0]))
+		if host == "" {
+			return "", "", "", invalid
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", invalid
+	}
+	return host, parts[0], parts[1], nil
+}
+
+// parsePRReference separa uma referência owner/name#123 ou host/owner/name#123
+func parsePRReference(value string) (string, string, string, int, error) {
+	repo, numberStr, ok := strings.Cut(strings.TrimSpace(value), "#")
+	if !ok {
+		return "", "", "", 0, fmt.Errorf("PR inválido: %q (use owner/name#123 ou host/owner/name#123)", value)
+	}
+
+	host, owner, name, err := parseRepoName(repo)
+	if err != nil {
+		return "", "", "", 0, fmt.Errorf("PR inválido: %q (use owner/name#123 ou host/owner/name#123)", value)
+	}
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number <= 0 {
+		return "", "", "", 0, fmt.Errorf("PR inválido: %q (use owner/name#123 ou host/owner/name#123)", value)
+	}
+	return host, owner, name, number, nil
+}
+
+// fileSize retorna o tamanho do arquivo ou 0 se não for possível lê-lo
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// formatBytes formata um tamanho em bytes com a maior unidade adequada
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size)
+	suffixes := []string{"KB", "MB", "GB", "TB"}
+	i := -1
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
+
+func init() {
+	cacheCmd.PersistentFlags().String("database", defaultDatabasePath, "Arquivo do banco SQLite")
+	cacheStatsCmd.Flags().String("cache-ttl", "7d", "Validade do cache de comentários e reações, como no relatório (ex: 7d, 2w ou 12h)")
+	cacheStatsCmd.Flags().String("freeze-after-merge", "", "Congelamento após o merge usado no relatório (ex: 3d); vazio = desativado")
+	cacheStatsCmd.Flags().Bool("refresh-current-week", false, "Conta como desatualizados os comentários de PRs mergeados na semana atual")
+	cacheStatsCmd.Flags().String("timezone", "UTC", "Fuso horário IANA que define a semana atual (ex: America/Sao_Paulo)")
+	cacheStatsCmd.Flags().String("week-start", "monday", "Dia de início da semana: monday ou sunday")
+	cachePruneCmd.Flags().String("repo", "", "Repositório no formato owner/name ou host/owner/name (padrão: todos)")
+	cachePruneCmd.Flags().String("older-than", "", "Remove PRs mergeados há mais tempo que o informado (ex: 90d ou 12w)")
+	cacheInvalidateCmd.Flags().String("pr", "", "PR no formato owner/name#123 ou host/owner/name#123")
+
+	cacheCmd.AddCommand(cacheStatsCmd, cachePruneCmd, cacheInvalidateCmd, cacheVacuumCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
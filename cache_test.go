@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestParsePRReference(t *testing.T) {
+	host, owner, name, number, err := parsePRReference("org/api#123")
+	if err != nil || host != "" || owner != "org" || name != "api" || number != 123 {
+		t.Errorf("parsePRReference() = %s, %s, %s, %d, %v", host, owner, name, number, err)
+	}
+
+	host, owner, name, number, err = parsePRReference("GHE.corp.com/org/api#7")
+	if err != nil || host != "ghe.corp.com" || owner != "org" || name != "api" || number != 7 {
+		t.Errorf("parsePRReference() = %s, %s, %s, %d, %v", host, owner, name, number, err)
+	}
+
+	for _, invalid := range []string{"org/api", "org#1", "org/api#abc", "org/api#0", "/api#1", "/org/api#1", "a/b/c/d#1"} {
+		if _, _, _, _, err := parsePRReference(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KB",
+		5 * 1024 * 1024: "5.0 MB",
+	}
+	for size, expected := range tests {
+		if got := formatBytes(size); got != expected {
+			t.Errorf("formatBytes(%d) = %q, expected %q", size, got, expected)
+		}
+	}
+}
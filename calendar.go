@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WeekCalendar define como os instantes são agrupados em períodos: o fuso horário usado
+// para determinar o dia local, o dia em que a semana começa e a granularidade do período
+type WeekCalendar struct {
+	Location  *time.Location
+	WeekStart time.Weekday
+	Period    PeriodSpec
+}
+
+// DefaultWeekCalendar retorna o calendário padrão: semanas de segunda a domingo em UTC
+func DefaultWeekCalendar() WeekCalendar {
+	return WeekCalendar{Location: time.UTC, WeekStart: time.Monday, Period: DefaultPeriod}
+}
+
+// NewWeekCalendar cria um calendário a partir de um fuso IANA (ex: America/Sao_Paulo)
+// e do dia de início da semana (monday ou sunday). Valores vazios usam o padrão.
+func NewWeekCalendar(timezone, weekStart string) (WeekCalendar, error) {
+	calendar := DefaultWeekCalendar()
+
+	if timezone = strings.TrimSpace(timezone); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return WeekCalendar{}, fmt.Errorf("fuso horário inválido: %s (use um nome IANA, ex: America/Sao_Paulo)", timezone)
+		}
+		calendar.Location = location
+	}
+
+	switch strings.ToLower(strings.TrimSpace(weekStart)) {
+	case "", "monday", "segunda":
+		calendar.WeekStart = time.Monday
+	case "sunday", "domingo":
+		calendar.WeekStart = time.Sunday
+	default:
+		return WeekCalendar{}, fmt.Errorf("início de semana inválido: %s (use monday ou sunday)", weekStart)
+	}
+
+	return calendar, nil
+}
+
+// location retorna o fuso do calendário (UTC quando não definido)
+func (c WeekCalendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+// WeekStartOf retorna a meia-noite local do primeiro dia da semana que contém t
+func (c WeekCalendar) WeekStartOf(t time.Time) time.Time {
+	local := t.In(c.location())
+	daysBack := (int(local.Weekday()) - int(c.WeekStart) + 7) % 7
+	return time.Date(local.Year(), local.Month(), local.Day()-daysBack, 0, 0, 0, 0, c.location())
+}
+
+// WeekEndOf retorna o último dia da semana iniciada em weekStart.
+// Usa AddDate para não ser afetado por mudanças de horário de verão.
+func (c WeekCalendar) WeekEndOf(weekStart time.Time) time.Time {
+	return weekStart.AddDate(0, 0, 6)
+}
+
+// SetWeekCalendar define o fuso e o início de semana usados no agrupamento semanal
+func (pc *PRChampion) SetWeekCalendar(calendar WeekCalendar) {
+	pc.calendar = &calendar
+}
+
+// weekCalendar retorna o calendário em uso (ou o padrão)
+func (pc *PRChampion) weekCalendar() WeekCalendar {
+	if pc.calendar == nil {
+		return DefaultWeekCalendar()
+	}
+	return *pc.calendar
+}
@@ -0,0 +1,48 @@
+package main
+
+import "sync"
+
+// DefaultConcurrency é o número padrão de PRs processados em paralelo
+const DefaultConcurrency = 4
+
+// SetConcurrency define quantos PRs têm comentários e reações buscados em paralelo
+func (pc *PRChampion) SetConcurrency(workers int) {
+	pc.concurrency = workers
+}
+
+// activeConcurrency retorna o número de workers em uso (mínimo 1)
+func (pc *PRChampion) activeConcurrency() int {
+	if pc.concurrency < 1 {
+		return 1
+	}
+	return pc.concurrency
+}
+
+// runWorkerPool executa fn para cada índice de 0 a n-1 usando no máximo workers goroutines.
+// Retorna somente quando todas as execuções terminarem.
+func runWorkerPool(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// fakeCommentsClient é um GithubAdapter em memória que registra o paralelismo das chamadas
+type fakeCommentsClient struct {
+	issueComments  map[int][]*github.IssueComment
+	reviewComments map[int][]*github.PullRequestComment
+	reactions      map[int64][]*github.Reaction
+	failPR         int // PR cujos comentários retornam erro (0 = nenhum)
+
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (f *fakeCommentsClient) track() func() {
+	current := atomic.AddInt32(&f.inFlight, 1)
+	for {
+		max := atomic.LoadInt32(&f.maxInFlight)
+		if current <= max || atomic.CompareAndSwapInt32(&f.maxInFlight, max, current) {
+			break
+		}
+	}
+	time.Sleep(2 * time.Millisecond) // Simula a latência da API
+	return func() { atomic.AddInt32(&f.inFlight, -1) }
+}
+
+func (f *fakeCommentsClient) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeCommentsClient) GetPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeCommentsClient) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	defer f.track()()
+	return f.reactions[commentID], nil
+}
+
+func (f *fakeCommentsClient) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	defer f.track()()
+	return f.reactions[commentID], nil
+}
+
+func (f *fakeCommentsClient) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	defer f.track()()
+	if prNumber == f.failPR {
+		return nil, fmt.Errorf("falha simulada")
+	}
+	return f.issueComments[prNumber], nil
+}
+
+func (f *fakeCommentsClient) ListPRReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.PullRequestComment, error) {
+	defer f.track()()
+	return f.reviewComments[prNumber], nil
+}
+
+// newFakeCommentsFixture cria 12 PRs, cada um com um comentário e um review comment de revisores diferentes
+func newFakeCommentsFixture() (*fakeCommentsClient, []*github.PullRequest) {
+	monday := time.Date(2024, 9, 30, 10, 0, 0, 0, time.UTC)
+	client := &fakeCommentsClient{
+		issueComments:  make(map[int][]*github.IssueComment),
+		reviewComments: make(map[int][]*github.PullRequestComment),
+		reactions:      make(map[int64][]*github.Reaction),
+	}
+
+	var prs []*github.PullRequest
+	reviewers := []string{"alice", "bob", "carol"}
+	for n := 1; n <= 12; n++ {
+		mergedAt := monday.Add(time.Duration(n) * 12 * time.Hour)
+		prs = append(prs, newTestPR("org", "api", "author", n, mergedAt))
+
+		commentID := int64(n * 10)
+		client.issueComments[n] = []*github.IssueComment{{
+			ID:        github.Int64(commentID),
+			User:      &github.User{Login: github.String(reviewers[n%3])},
+			CreatedAt: &github.Timestamp{Time: mergedAt.Add(-time.Hour)},
+		}}
+		client.reviewComments[n] = []*github.PullRequestComment{{
+			ID:        github.Int64(commentID + 1),
+			User:      &github.User{Login: github.String(reviewers[(n+1)%3])},
+			CreatedAt: &github.Timestamp{Time: mergedAt.Add(-time.Hour)},
+		}}
+		client.reactions[commentID] = []*github.Reaction{{
+			Content:   github.String("+1"),
+			CreatedAt: &github.Timestamp{Time: mergedAt.Add(-time.Minute)},
+		}}
+	}
+
+	return client, prs
+}
+
+func TestRunWorkerPoolVisitsEveryIndexOnce(t *testing.T) {
+	var mu sync.Mutex
+	visits := make(map[int]int)
+
+	runWorkerPool(50, 8, func(i int) {
+		mu.Lock()
+		visits[i]++
+		mu.Unlock()
+	})
+
+	if len(visits) != 50 {
+		t.Fatalf("Expected 50 indexes visited, got %d", len(visits))
+	}
+	for i, count := range visits {
+		if count != 1 {
+			t.Errorf("Index %d visited %d times", i, count)
+		}
+	}
+
+	runWorkerPool(0, 4, func(i int) { t.Error("fn should not be called for n = 0") })
+}
+
+func TestFetchCommentsForPRsConcurrentMatchesSequential(t *testing.T) {
+	run := func(workers int) (*PRChampion, *fakeCommentsClient) {
+		client, prs := newFakeCommentsFixture()
+		pc := &PRChampion{client: client, userStats: make(map[string]*UserStats)}
+		pc.SetConcurrency(workers)
+		pc.processWeeklyData(prs)
+		if err := pc.fetchCommentsForPRs(prs); err != nil {
+			t.Fatalf("fetchCommentsForPRs() error = %v", err)
+		}
+		pc.calculateUserStats()
+		return pc, client
+	}
+
+	sequential, _ := run(1)
+	concurrent, client := run(6)
+
+	if atomic.LoadInt32(&client.maxInFlight) < 2 {
+		t.Errorf("Expected API calls to overlap with 6 workers, max in flight = %d", client.maxInFlight)
+	}
+
+	for i := range sequential.weeklyData {
+		seq, con := sequential.weeklyData[i], concurrent.weeklyData[i]
+		if !reflect.DeepEqual(seq.UserComments, con.UserComments) ||
+			!reflect.DeepEqual(seq.UserWeightedComments, con.UserWeightedComments) ||
+			!reflect.DeepEqual(seq.RepoComments, con.RepoComments) {
+			t.Errorf("Week %d differs between sequential and concurrent runs", i)
+		}
+		if seq.CommentWinner != con.CommentWinner || seq.WeightedCommentWinner != con.WeightedCommentWinner {
+			t.Errorf("Week %d winners differ: %s/%s vs %s/%s", i,
+				seq.CommentWinner, seq.WeightedCommentWinner, con.CommentWinner, con.WeightedCommentWinner)
+		}
+	}
+
+	if !reflect.DeepEqual(sequential.scorerTotals, concurrent.scorerTotals) {
+		t.Errorf("Scorer totals differ: %v vs %v", sequential.scorerTotals, concurrent.scorerTotals)
+	}
+}
+
+func TestFetchCommentsForPRsRecordsFailedPR(t *testing.T) {
+	client, prs := newFakeCommentsFixture()
+	client.failPR = 4
+
+	pc := &PRChampion{client: client, userStats: make(map[string]*UserStats)}
+	pc.SetConcurrency(4)
+	pc.processWeeklyData(prs)
+	if err := pc.fetchCommentsForPRs(prs); err != nil {
+		t.Fatalf("fetchCommentsForPRs() error = %v", err)
+	}
+
+	total := 0
+	for _, week := range pc.weeklyData {
+		for _, count := range week.UserComments {
+			total += count
+		}
+	}
+
+	// A falha no PR #4 não interrompe a análise: os outros 11 PRs (dois comentários cada) são agregados
+	if total != 22 {
+		t.Errorf("Expected 22 comments aggregated despite the failed PR, got %d", total)
+	}
+	if !pc.Incomplete() || len(pc.FetchErrors()) != 1 {
+		t.Errorf("Expected report to be incomplete with 1 error, got %v", pc.FetchErrors())
+	}
+}
+
+func TestFetchCommentsForPRsWritesProgressToConfiguredOutput(t *testing.T) {
+	client, prs := newFakeCommentsFixture()
+
+	var progress bytes.Buffer
+	pc := &PRChampion{client: client, userStats: make(map[string]*UserStats)}
+	pc.SetProgressOutput(&progress)
+	pc.processWeeklyData(prs)
+	if err := pc.fetchCommentsForPRs(prs); err != nil {
+		t.Fatalf("fetchCommentsForPRs() error = %v", err)
+	}
+
+	if !strings.Contains(progress.String(), "💬 Total de comentários") || !strings.Contains(progress.String(), "⏱️") {
+		t.Errorf("Expected progress messages in the configured output, got %q", progress.String())
+	}
+}
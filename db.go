@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Administra o banco SQLite usado como cache",
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Aplica as migrações pendentes do esquema do banco",
+	Long: `Aplica as migrações pendentes do esquema do banco SQLite.
+
+As migrações também rodam automaticamente ao abrir o banco; este comando permite
+atualizar o arquivo de forma explícita ou, com --dry-run, apenas listar os passos pendentes.
+
+Exemplo:
+  pr-champion db migrate --dry-run`,
+	Run: runDBMigrate,
+}
+
+// runDBMigrate lista ou aplica as migrações pendentes do banco
+func runDBMigrate(cmd *cobra.Command, args []string) {
+	dbPath, _ := cmd.Flags().GetString("database")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if dryRun {
+		pending, err := database.PendingMigrations(dbPath)
+		if err != nil {
+			log.Fatalf("❌ Erro ao verificar migrações: %v", err)
+		}
+		if len(pending) == 0 {
+			fmt.Printf("✅ Banco %s já está atualizado\n", dbPath)
+			return
+		}
+		fmt.Printf("📋 %d migrações pendentes em %s:\n", len(pending), dbPath)
+		printMigrations(pending)
+		return
+	}
+
+	applied, err := database.MigrateDatabase(dbPath)
+	if err != nil {
+		log.Fatalf("❌ Erro ao migrar banco: %v", err)
+	}
+	if len(applied) == 0 {
+		fmt.Printf("✅ Banco %s já está atualizado\n", dbPath)
+		return
+	}
+	fmt.Printf("✅ %d migrações aplicadas em %s:\n", len(applied), dbPath)
+	printMigrations(applied)
+}
+
+// printMigrations exibe uma migração por linha com sua versão
+func printMigrations(migrations []database.Migration) {
+	for _, migration := range migrations {
+		fmt.Printf("  • v%d: %s\n", migration.Version, migration.Description)
+	}
+}
+
+func init() {
+	dbCmd.PersistentFlags().String("database", defaultDatabasePath, "Arquivo do banco SQLite")
+	dbMigrateCmd.Flags().Bool("dry-run", false, "Apenas lista as migrações pendentes, sem alterar o banco")
+	dbCmd.AddCommand(dbMigrateCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// RepoDiscoveryOptions descreve como descobrir os repositórios de uma organização
+type RepoDiscoveryOptions struct {
+	Org                string
+	ProductionBranches []string // Sobrescreve a branch padrão de cada repositório descoberto
+	Topics             []string // O repositório precisa ter todos os tópicos
+	Include            []string // Padrões glob de nomes aceitos (vazio = todos)
+	Exclude            []string // Padrões glob de nomes ignorados
+	SkipArchived       bool
+}
+
+// ParseOrgSpec separa a organização das branches de produção: "myorg" ou "myorg:main|master"
+func ParseOrgSpec(spec string) (string, []string) {
+	org, branchesStr, _ := strings.Cut(strings.TrimSpace(spec), ":")
+
+	return strings.TrimSpace(org), splitBranchPatterns(branchesStr)
+}
+
+// Validate verifica se os padrões de include/exclude são globs válidos
+func (o RepoDiscoveryOptions) Validate() error {
+	for _, pattern := range append(append([]string{}, o.Include...), o.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("padrão inválido %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matches indica se o repositório descoberto passa pelos filtros
+func (o RepoDiscoveryOptions) matches(repo *infrastructure.DiscoveredRepository) bool {
+	if o.SkipArchived && repo.Archived {
+		return false
+	}
+
+	name := strings.ToLower(repo.Name)
+	if len(o.Include) > 0 && !matchesAnyPattern(name, o.Include) {
+		return false
+	}
+	if matchesAnyPattern(name, o.Exclude) {
+		return false
+	}
+
+	for _, topic := range o.Topics {
+		if !containsFold(repo.Topics, topic) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyPattern indica se o nome casa com algum dos padrões glob (sem diferenciar maiúsculas)
+func matchesAnyPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscoverRepositories lista os repositórios da organização e adiciona os que passam pelos filtros.
+// Repositórios informados explicitamente (--repos) têm precedência e mantêm suas branches.
+func (pc *PRChampion) DiscoverRepositories(opts RepoDiscoveryOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	discoverer, ok := pc.client.(infrastructure.RepositoryDiscoverer)
+	if !ok {
+		return fmt.Errorf("o cliente atual não lista repositórios de organizações")
+	}
+
+	fmt.Fprintf(pc.progress(), "🔎 Descobrindo repositórios da organização %s...\n", opts.Org)
+	discovered, err := discoverer.ListOrgRepositories(context.Background(), opts.Org)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(discovered, func(i, j int) bool {
+		return strings.ToLower(discovered[i].Name) < strings.ToLower(discovered[j].Name)
+	})
+
+	explicit := make(map[string]bool)
+	for _, repo := range pc.repositories {
+		if pc.repositoryHost(repo) == pc.repositoryHost(Repository{}) {
+			explicit[strings.ToLower(repo.Owner+"/"+repo.Name)] = true
+		}
+	}
+
+	added, filtered := 0, 0
+	for _, repo := range discovered {
+		if !opts.matches(repo) {
+			filtered++
+			continue
+		}
+		if explicit[strings.ToLower(repo.Owner+"/"+repo.Name)] {
+			continue
+		}
+
+		productionBranches := opts.ProductionBranches
+		if len(productionBranches) == 0 {
+			productionBranches = []string{repo.DefaultBranch}
+			if repo.DefaultBranch == "" {
+				productionBranches = []string{"main"}
+			}
+		}
+
+		pc.repositories = append(pc.repositories, Repository{
+			Owner:              repo.Owner,
+			Name:               repo.Name,
+			ProductionBranches: productionBranches,
+		})
+		added++
+	}
+
+	fmt.Fprintf(pc.progress(), "  ✅ %d repositórios adicionados de %s (%d ignorados pelos filtros)\n", added, opts.Org, filtered)
+
+	// A organização é listada no host padrão; um repositório de mesmo nome qualificado com outro host colidiria
+	return checkRepositoryHosts(pc.repositories, pc.defaultHost)
+}
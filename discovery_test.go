@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// fakeDiscoveryClient adiciona a listagem de repositórios ao cliente em memória dos testes
+type fakeDiscoveryClient struct {
+	fakeCommentsClient
+	repos []*infrastructure.DiscoveredRepository
+}
+
+func (f *fakeDiscoveryClient) ListOrgRepositories(ctx context.Context, org string) ([]*infrastructure.DiscoveredRepository, error) {
+	return f.repos, nil
+}
+
+func newFakeDiscoveryClient() *fakeDiscoveryClient {
+	return &fakeDiscoveryClient{repos: []*infrastructure.DiscoveredRepository{
+		{Owner: "org", Name: "svc-payments", DefaultBranch: "main", Topics: []string{"backend", "team-a"}},
+		{Owner: "org", Name: "svc-billing", DefaultBranch: "master", Topics: []string{"backend"}},
+		{Owner: "org", Name: "svc-old-archive", DefaultBranch: "main", Topics: []string{"backend"}},
+		{Owner: "org", Name: "svc-legacy", DefaultBranch: "main", Archived: true, Topics: []string{"backend"}},
+		{Owner: "org", Name: "web", DefaultBranch: "trunk"},
+	}}
+}
+
+func TestParseOrgSpec(t *testing.T) {
+	org, branches := ParseOrgSpec("myorg")
+	if org != "myorg" || branches != nil {
+		t.Errorf("Expected myorg without branches, got %s %v", org, branches)
+	}
+
+	org, branches = ParseOrgSpec("myorg:release|main")
+	if org != "myorg" || !reflect.DeepEqual(branches, []string{"release", "main"}) {
+		t.Errorf("Expected myorg with [release main], got %s %v", org, branches)
+	}
+}
+
+func TestDiscoverRepositoriesAppliesFilters(t *testing.T) {
+	pc := &PRChampion{client: newFakeDiscoveryClient(), userStats: make(map[string]*UserStats)}
+
+	err := pc.DiscoverRepositories(RepoDiscoveryOptions{
+		Org:          "org",
+		Topics:       []string{"backend"},
+		Include:      []string{"svc-*"},
+		Exclude:      []string{"*-archive"},
+		SkipArchived: true,
+	})
+	if err != nil {
+		t.Fatalf("DiscoverRepositories() error = %v", err)
+	}
+
+	expected := []Repository{
+		{Owner: "org", Name: "svc-billing", ProductionBranches: []string{"master"}},
+		{Owner: "org", Name: "svc-payments", ProductionBranches: []string{"main"}},
+	}
+	if !reflect.DeepEqual(pc.repositories, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, pc.repositories)
+	}
+}
+
+func TestDiscoverRepositoriesKeepsExplicitRepositories(t *testing.T) {
+	pc := &PRChampion{
+		client:       newFakeDiscoveryClient(),
+		userStats:    make(map[string]*UserStats),
+		repositories: []Repository{{Owner: "org", Name: "web", ProductionBranches: []string{"production"}}},
+	}
+
+	err := pc.DiscoverRepositories(RepoDiscoveryOptions{
+		Org:                "org",
+		ProductionBranches: []string{"release"},
+		Include:            []string{"web", "svc-pay*"},
+	})
+	if err != nil {
+		t.Fatalf("DiscoverRepositories() error = %v", err)
+	}
+
+	// O repositório explícito mantém suas branches; os descobertos usam as branches do --org
+	expected := []Repository{
+		{Owner: "org", Name: "web", ProductionBranches: []string{"production"}},
+		{Owner: "org", Name: "svc-payments", ProductionBranches: []string{"release"}},
+	}
+	if !reflect.DeepEqual(pc.repositories, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, pc.repositories)
+	}
+}
+
+func TestDiscoverRepositoriesRejectsRepositoryOnAnotherHost(t *testing.T) {
+	pc := &PRChampion{
+		client:       newFakeDiscoveryClient(),
+		userStats:    make(map[string]*UserStats),
+		repositories: []Repository{{Host: "ghe.corp.com", Owner: "org", Name: "web", ProductionBranches: []string{"main"}}},
+	}
+
+	if err := pc.DiscoverRepositories(RepoDiscoveryOptions{Org: "org", Include: []string{"web"}}); err == nil {
+		t.Error("Expected an error for org/web on the default host and on ghe.corp.com")
+	}
+}
+
+func TestDiscoverRepositoriesRejectsInvalidPattern(t *testing.T) {
+	pc := &PRChampion{client: newFakeDiscoveryClient(), userStats: make(map[string]*UserStats)}
+	if err := pc.DiscoverRepositories(RepoDiscoveryOptions{Org: "org", Include: []string{"svc-["}}); err == nil {
+		t.Error("Expected error for invalid glob pattern")
+	}
+}
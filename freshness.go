@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// ParseFreshnessPolicy monta a política de validade do cache a partir das flags
+// --cache-ttl, --freeze-after-merge e --refresh-current-week
+func ParseFreshnessPolicy(ttl, freezeAfterMerge string, refreshCurrentWeek bool) (infrastructure.FreshnessPolicy, error) {
+	policy := infrastructure.DefaultFreshnessPolicy()
+	policy.RefreshCurrentWeek = refreshCurrentWeek
+
+	if strings.TrimSpace(ttl) != "" {
+		duration, err := parseCacheDuration(ttl)
+		if err != nil {
+			return infrastructure.FreshnessPolicy{}, fmt.Errorf("--cache-ttl inválido: %v", err)
+		}
+		policy.TTL = duration
+	}
+
+	if strings.TrimSpace(freezeAfterMerge) != "" {
+		duration, err := parseCacheDuration(freezeAfterMerge)
+		if err != nil {
+			return infrastructure.FreshnessPolicy{}, fmt.Errorf("--freeze-after-merge inválido: %v", err)
+		}
+		policy.FreezeAfterMerge = duration
+	}
+
+	return policy, nil
+}
+
+// parseCacheDuration aceita dias e semanas (ex: 7d, 2w) ou durações do Go (ex: 12h)
+func parseCacheDuration(value string) (time.Duration, error) {
+	if days, err := parseDayDuration(value); err == nil {
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("duração inválida: %q (use, por exemplo, 7d, 2w ou 12h)", value)
+	}
+	return duration, nil
+}
+
+// SetFreshnessPolicy define quando comentários e reações em cache são buscados novamente
+func (pc *PRChampion) SetFreshnessPolicy(policy infrastructure.FreshnessPolicy) {
+	pc.freshness = &policy
+	if configurable, ok := pc.cachedClient.(infrastructure.FreshnessConfigurable); ok {
+		configurable.SetFreshnessPolicy(policy)
+	}
+}
+
+// freshnessPolicy retorna a política de validade em uso (ou a padrão)
+func (pc *PRChampion) freshnessPolicy() infrastructure.FreshnessPolicy {
+	if pc.freshness == nil {
+		return infrastructure.DefaultFreshnessPolicy()
+	}
+	return *pc.freshness
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+func TestParseFreshnessPolicy(t *testing.T) {
+	policy, err := ParseFreshnessPolicy("2w", "3d", true)
+	if err != nil {
+		t.Fatalf("ParseFreshnessPolicy() error = %v", err)
+	}
+	expected := infrastructure.FreshnessPolicy{TTL: 14 * 24 * time.Hour, FreezeAfterMerge: 72 * time.Hour, RefreshCurrentWeek: true}
+	if policy != expected {
+		t.Errorf("Expected %+v, got %+v", expected, policy)
+	}
+
+	policy, err = ParseFreshnessPolicy("12h", "", false)
+	if err != nil || policy.TTL != 12*time.Hour || policy.FreezeAfterMerge != 0 {
+		t.Errorf("Unexpected policy %+v (%v)", policy, err)
+	}
+
+	for _, invalid := range []string{"7x", "-1h", "abc"} {
+		if _, err := ParseFreshnessPolicy(invalid, "", false); err == nil {
+			t.Errorf("Expected error for TTL %q", invalid)
+		}
+	}
+}
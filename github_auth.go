@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// resolveGithubApp monta as credenciais da GitHub App a partir das flags ou das variáveis
+// GITHUB_APP_ID, GITHUB_APP_INSTALLATION_ID e GITHUB_APP_PRIVATE_KEY_PATH.
+// Retorna nil quando nenhuma GitHub App foi configurada (autenticação por token).
+func resolveGithubApp(appID, installationID int64, privateKeyPath string) (*infrastructure.GithubAppCredentials, error) {
+	var err error
+	if appID == 0 {
+		if appID, err = int64FromEnv("GITHUB_APP_ID"); err != nil {
+			return nil, err
+		}
+	}
+	if installationID == 0 {
+		if installationID, err = int64FromEnv("GITHUB_APP_INSTALLATION_ID"); err != nil {
+			return nil, err
+		}
+	}
+	if privateKeyPath == "" {
+		privateKeyPath = os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH")
+	}
+
+	if appID == 0 && installationID == 0 && privateKeyPath == "" {
+		return nil, nil
+	}
+	if privateKeyPath == "" {
+		return nil, fmt.Errorf("GitHub App requer a chave privada (--app-private-key ou GITHUB_APP_PRIVATE_KEY_PATH)")
+	}
+
+	return infrastructure.LoadGithubAppCredentials(appID, installationID, privateKeyPath)
+}
+
+// int64FromEnv lê um número inteiro de uma variável de ambiente (0 quando ausente)
+func int64FromEnv(name string) (int64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("valor inválido em %s: %v", name, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/thrcorrea/PRPG/internal/database"
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// hostTokenEnv retorna a variável de ambiente com o token de um host, ex: GITHUB_TOKEN_GHE_CORP_COM
+func hostTokenEnv(host string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, host)
+	return "GITHUB_TOKEN_" + name
+}
+
+// hostClientOptions deriva as opções do cliente de um host diferente do padrão. Cada instância exige
+// seu próprio token em GITHUB_TOKEN_<HOST>: o token e a GitHub App padrão nunca são enviados a outro host
+func hostClientOptions(defaults infrastructure.ClientOptions, host string) (infrastructure.ClientOptions, error) {
+	opts := defaults
+	opts.BaseURL = host
+	if infrastructure.NormalizeHost(host) == database.DefaultHost {
+		opts.BaseURL = ""
+	}
+
+	opts.App = nil
+	opts.Token = os.Getenv(hostTokenEnv(host))
+	if opts.Token == "" {
+		return opts, fmt.Errorf("defina %s com um token para %s; as credenciais padrão não são enviadas a outros hosts",
+			hostTokenEnv(host), host)
+	}
+	return opts, nil
+}
+
+// repositoryHost retorna o host normalizado do repositório; repositórios sem host usam defaultHost
+// (host ou URL do GitHub padrão da execução, vazio = github.com)
+func repositoryHost(repo Repository, defaultHost string) string {
+	if repo.Host != "" {
+		return infrastructure.NormalizeHost(repo.Host)
+	}
+	return infrastructure.NormalizeHost(defaultHost)
+}
+
+// checkRepositoryHosts rejeita o mesmo owner/repo em hosts diferentes na mesma execução: o roteamento
+// das chamadas e as estatísticas do relatório identificam os repositórios apenas por owner/repo.
+// O cache local separa os dados por host, então cada host pode ser analisado em sua própria execução
+func checkRepositoryHosts(repositories []Repository, defaultHost string) error {
+	hosts := make(map[string]string)
+	for _, repo := range repositories {
+		key := strings.ToLower(repo.Owner + "/" + repo.Name)
+		host := repositoryHost(repo, defaultHost)
+		if previous, seen := hosts[key]; seen && previous != host {
+			return fmt.Errorf("o repositório %s/%s foi informado em dois hosts (%s e %s); analise-os em execuções separadas",
+				repo.Owner, repo.Name, previous, host)
+		}
+		hosts[key] = host
+	}
+	return nil
+}
+
+// SetDefaultHost define o host (ou URL) dos repositórios sem host e informa ao cliente o host de cada
+// repositório, usado para separar os dados no cache local
+func (pc *PRChampion) SetDefaultHost(host string) {
+	pc.defaultHost = infrastructure.NormalizeHost(host)
+
+	hosts := infrastructure.NewRepositoryHosts(pc.defaultHost)
+	for _, repo := range pc.repositories {
+		if repo.Host != "" {
+			hosts.Route(repo.Owner, repo.Name, repo.Host)
+		}
+	}
+	if configurable, ok := pc.cachedClient.(infrastructure.HostConfigurable); ok {
+		configurable.SetRepositoryHosts(hosts)
+	}
+}
+
+// repositoryHost retorna o host do repositório nesta execução
+func (pc *PRChampion) repositoryHost(repo Repository) string {
+	return repositoryHost(repo, pc.defaultHost)
+}
+
+// newGithubClientForRepositories cria o cliente padrão e, para repositórios qualificados com host,
+// um cliente por host, roteando cada repositório para o cliente correspondente
+func newGithubClientForRepositories(opts infrastructure.ClientOptions, repositories []Repository) (infrastructure.GithubAdapter, error) {
+	defaultClient, err := infrastructure.NewGithubClientWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var router *infrastructure.RoutedGithubAdapter
+	hostClients := make(map[string]infrastructure.GithubAdapter)
+	defaultHost := infrastructure.NormalizeHost(opts.BaseURL)
+
+	for _, repo := range repositories {
+		// Repositórios do host padrão, qualificados ou não, usam o cliente padrão
+		host := repositoryHost(repo, defaultHost)
+		if host == defaultHost {
+			continue
+		}
+
+		client, ok := hostClients[host]
+		if !ok {
+			hostOpts, err := hostClientOptions(opts, host)
+			if err != nil {
+				return nil, err
+			}
+			client, err = infrastructure.NewGithubClientWithOptions(hostOpts)
+			if err != nil {
+				return nil, fmt.Errorf("erro ao criar cliente para %s: %v", host, err)
+			}
+			hostClients[host] = client
+		}
+
+		if router == nil {
+			router = infrastructure.NewRoutedGithubAdapter(defaultClient)
+		}
+		router.Route(repo.Owner, repo.Name, client)
+	}
+
+	if router == nil {
+		return defaultClient, nil
+	}
+	return router, nil
+}
@@ -0,0 +1,421 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Migration é um passo versionado de evolução do esquema do banco.
+// Cada migração roda em uma transação própria e a versão é registrada em schema_version
+// junto com as alterações, de modo que uma falha não deixa o banco pela metade
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(tx *sql.Tx) error
+}
+
+// migrations lista os passos do esquema em ordem crescente de versão.
+// Migrações já publicadas não devem ser alteradas: mudanças de esquema entram como uma nova versão
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "cria as tabelas comments, prs e reactions",
+		Apply: func(tx *sql.Tx) error {
+			// IF NOT EXISTS adota bancos criados antes do controle de versão
+			return execAll(tx,
+				`CREATE TABLE IF NOT EXISTS comments (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					pr_number INTEGER NOT NULL,
+					comment_id INTEGER NOT NULL UNIQUE,
+					comment_type TEXT NOT NULL,
+					username TEXT NOT NULL,
+					body TEXT,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					cached_at DATETIME NOT NULL,
+					reactions_checked BOOLEAN DEFAULT FALSE,
+					UNIQUE(comment_id)
+				);`,
+				`CREATE TABLE IF NOT EXISTS prs (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					pr_number INTEGER NOT NULL,
+					title TEXT NOT NULL,
+					username TEXT NOT NULL,
+					merged_at DATETIME NOT NULL,
+					has_comments BOOLEAN DEFAULT FALSE,
+					has_issue_comments BOOLEAN DEFAULT FALSE,
+					has_review_comments BOOLEAN DEFAULT FALSE,
+					comments_checked BOOLEAN DEFAULT FALSE,
+					issue_comments_checked BOOLEAN DEFAULT FALSE,
+					review_comments_checked BOOLEAN DEFAULT FALSE,
+					cached_at DATETIME NOT NULL,
+					UNIQUE(repo_owner, repo_name, pr_number)
+				);`,
+				`CREATE TABLE IF NOT EXISTS reactions (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					comment_id INTEGER NOT NULL,
+					reaction_type TEXT NOT NULL DEFAULT 'issue_comment',
+					content TEXT NOT NULL,
+					username TEXT NOT NULL,
+					cached_at DATETIME NOT NULL,
+					FOREIGN KEY(comment_id) REFERENCES comments(comment_id),
+					UNIQUE(comment_id, reaction_type, content, username)
+				);`,
+				`CREATE INDEX IF NOT EXISTS idx_comments_repo_pr ON comments(repo_owner, repo_name, pr_number);`,
+				`CREATE INDEX IF NOT EXISTS idx_comments_comment_id ON comments(comment_id);`,
+				`CREATE INDEX IF NOT EXISTS idx_reactions_comment_id ON reactions(comment_id);`,
+				`CREATE INDEX IF NOT EXISTS idx_comments_cached_at ON comments(cached_at);`,
+				`CREATE INDEX IF NOT EXISTS idx_prs_repo ON prs(repo_owner, repo_name);`,
+				`CREATE INDEX IF NOT EXISTS idx_prs_repo_pr ON prs(repo_owner, repo_name, pr_number);`,
+			)
+		},
+	},
+	{
+		Version:     2,
+		Description: "adiciona base_ref e updated_at em prs e a tabela repo_sync da sincronização incremental",
+		Apply: func(tx *sql.Tx) error {
+			if _, err := addColumnIfMissing(tx, "prs", "base_ref", "TEXT NOT NULL DEFAULT ''"); err != nil {
+				return err
+			}
+			if _, err := addColumnIfMissing(tx, "prs", "updated_at", "DATETIME"); err != nil {
+				return err
+			}
+			return execAll(tx,
+				`CREATE TABLE IF NOT EXISTS repo_sync (
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					synced_from DATETIME NOT NULL,
+					synced_until DATETIME NOT NULL,
+					last_synced_at DATETIME NOT NULL,
+					PRIMARY KEY(repo_owner, repo_name)
+				);`,
+				`CREATE INDEX IF NOT EXISTS idx_prs_repo_merged_at ON prs(repo_owner, repo_name, merged_at);`,
+			)
+		},
+	},
+	{
+		Version:     3,
+		Description: "adiciona reaction_id e created_at em reactions",
+		Apply: func(tx *sql.Tx) error {
+			if _, err := addColumnIfMissing(tx, "reactions", "reaction_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+				return err
+			}
+			addedCreatedAt, err := addColumnIfMissing(tx, "reactions", "created_at", "DATETIME")
+			if err != nil {
+				return err
+			}
+
+			// Reações salvas antes de existir created_at não podem ser filtradas pela data do merge:
+			// são descartadas e os comentários voltam a ter as reações verificadas na próxima execução
+			if addedCreatedAt {
+				return execAll(tx,
+					"DELETE FROM reactions",
+					"UPDATE comments SET reactions_checked = FALSE",
+				)
+			}
+			return nil
+		},
+	},
+	{
+		Version:     4,
+		Description: "cria a tabela repo_default_branch para resolver a branch padrão no modo offline",
+		Apply: func(tx *sql.Tx) error {
+			return execAll(tx,
+				`CREATE TABLE IF NOT EXISTS repo_default_branch (
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					default_branch TEXT NOT NULL,
+					resolved_at DATETIME NOT NULL,
+					PRIMARY KEY(repo_owner, repo_name)
+				);`,
+			)
+		},
+	},
+	{
+		Version:     5,
+		Description: "identifica PRs, comentários, reações e sincronizações pelo host do repositório",
+		Apply: func(tx *sql.Tx) error {
+			// O SQLite não altera chaves de tabelas existentes: cada tabela é recriada com o host na
+			// chave e os dados salvos até aqui, todos buscados sem host, são atribuídos ao github.com
+			return execAll(tx,
+				`CREATE TABLE prs_v5 (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					host TEXT NOT NULL DEFAULT 'github.com',
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					pr_number INTEGER NOT NULL,
+					title TEXT NOT NULL,
+					username TEXT NOT NULL,
+					merged_at DATETIME NOT NULL,
+					base_ref TEXT NOT NULL DEFAULT '',
+					updated_at DATETIME,
+					has_comments BOOLEAN DEFAULT FALSE,
+					has_issue_comments BOOLEAN DEFAULT FALSE,
+					has_review_comments BOOLEAN DEFAULT FALSE,
+					comments_checked BOOLEAN DEFAULT FALSE,
+					issue_comments_checked BOOLEAN DEFAULT FALSE,
+					review_comments_checked BOOLEAN DEFAULT FALSE,
+					cached_at DATETIME NOT NULL,
+					UNIQUE(host, repo_owner, repo_name, pr_number)
+				);`,
+				`INSERT INTO prs_v5 (id, host, repo_owner, repo_name, pr_number, title, username, merged_at, base_ref, updated_at,
+					has_comments, has_issue_comments, has_review_comments,
+					comments_checked, issue_comments_checked, review_comments_checked, cached_at)
+				SELECT id, 'github.com', repo_owner, repo_name, pr_number, title, username, merged_at, base_ref, updated_at,
+					has_comments, has_issue_comments, has_review_comments,
+					comments_checked, issue_comments_checked, review_comments_checked, cached_at
+				FROM prs;`,
+				`CREATE TABLE comments_v5 (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					host TEXT NOT NULL DEFAULT 'github.com',
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					pr_number INTEGER NOT NULL,
+					comment_id INTEGER NOT NULL,
+					comment_type TEXT NOT NULL,
+					username TEXT NOT NULL,
+					body TEXT,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					cached_at DATETIME NOT NULL,
+					reactions_checked BOOLEAN DEFAULT FALSE,
+					UNIQUE(host, comment_id)
+				);`,
+				`INSERT INTO comments_v5 (id, host, repo_owner, repo_name, pr_number, comment_id, comment_type, username, body,
+					created_at, updated_at, cached_at, reactions_checked)
+				SELECT id, 'github.com', repo_owner, repo_name, pr_number, comment_id, comment_type, username, body,
+					created_at, updated_at, cached_at, reactions_checked
+				FROM comments;`,
+				`CREATE TABLE reactions_v5 (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					host TEXT NOT NULL DEFAULT 'github.com',
+					comment_id INTEGER NOT NULL,
+					reaction_type TEXT NOT NULL DEFAULT 'issue_comment',
+					content TEXT NOT NULL,
+					username TEXT NOT NULL,
+					reaction_id INTEGER NOT NULL DEFAULT 0,
+					created_at DATETIME,
+					cached_at DATETIME NOT NULL,
+					FOREIGN KEY(host, comment_id) REFERENCES comments(host, comment_id),
+					UNIQUE(host, comment_id, reaction_type, content, username)
+				);`,
+				`INSERT INTO reactions_v5 (id, host, comment_id, reaction_type, content, username, reaction_id, created_at, cached_at)
+				SELECT id, 'github.com', comment_id, reaction_type, content, username, reaction_id, created_at, cached_at
+				FROM reactions;`,
+				`CREATE TABLE repo_sync_v5 (
+					host TEXT NOT NULL DEFAULT 'github.com',
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					synced_from DATETIME NOT NULL,
+					synced_until DATETIME NOT NULL,
+					last_synced_at DATETIME NOT NULL,
+					PRIMARY KEY(host, repo_owner, repo_name)
+				);`,
+				`INSERT INTO repo_sync_v5 (host, repo_owner, repo_name, synced_from, synced_until, last_synced_at)
+				SELECT 'github.com', repo_owner, repo_name, synced_from, synced_until, last_synced_at
+				FROM repo_sync;`,
+				`CREATE TABLE repo_default_branch_v5 (
+					host TEXT NOT NULL DEFAULT 'github.com',
+					repo_owner TEXT NOT NULL,
+					repo_name TEXT NOT NULL,
+					default_branch TEXT NOT NULL,
+					resolved_at DATETIME NOT NULL,
+					PRIMARY KEY(host, repo_owner, repo_name)
+				);`,
+				`INSERT INTO repo_default_branch_v5 (host, repo_owner, repo_name, default_branch, resolved_at)
+				SELECT 'github.com', repo_owner, repo_name, default_branch, resolved_at
+				FROM repo_default_branch;`,
+				`DROP TABLE reactions;`,
+				`DROP TABLE comments;`,
+				`DROP TABLE prs;`,
+				`DROP TABLE repo_sync;`,
+				`DROP TABLE repo_default_branch;`,
+				`ALTER TABLE prs_v5 RENAME TO prs;`,
+				`ALTER TABLE comments_v5 RENAME TO comments;`,
+				`ALTER TABLE reactions_v5 RENAME TO reactions;`,
+				`ALTER TABLE repo_sync_v5 RENAME TO repo_sync;`,
+				`ALTER TABLE repo_default_branch_v5 RENAME TO repo_default_branch;`,
+				`CREATE INDEX idx_comments_repo_pr ON comments(host, repo_owner, repo_name, pr_number);`,
+				`CREATE INDEX idx_comments_cached_at ON comments(cached_at);`,
+				`CREATE INDEX idx_reactions_comment_id ON reactions(host, comment_id);`,
+				`CREATE INDEX idx_prs_repo_merged_at ON prs(host, repo_owner, repo_name, merged_at);`,
+			)
+		},
+	},
+}
+
+// createSchemaVersionTable cria a tabela que registra as migrações aplicadas
+const createSchemaVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+	version INTEGER PRIMARY KEY,
+	description TEXT NOT NULL,
+	applied_at DATETIME NOT NULL
+);`
+
+// PendingMigrations retorna as migrações ainda não aplicadas ao banco informado, sem alterá-lo.
+// Um arquivo inexistente é tratado como banco vazio (todas as migrações pendentes)
+func PendingMigrations(dbPath string) ([]Migration, error) {
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return migrations, nil
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir banco SQLite: %v", err)
+	}
+	defer db.Close()
+
+	version, err := schemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	return pendingMigrations(migrations, version)
+}
+
+// MigrateDatabase aplica as migrações pendentes ao banco informado e retorna as que foram aplicadas
+func MigrateDatabase(dbPath string) ([]Migration, error) {
+	pending, err := PendingMigrations(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// NewSQLiteDatabase aplica as migrações pendentes ao abrir o banco
+	db, err := NewSQLiteDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return pending, db.Close()
+}
+
+// migrate aplica, em ordem e cada uma em sua transação, as migrações com versão maior que a atual
+func (db *sqliteDatabase) migrate(steps []Migration) error {
+	if _, err := db.db.Exec(createSchemaVersionTable); err != nil {
+		return fmt.Errorf("erro ao criar tabela schema_version: %v", err)
+	}
+
+	version, err := schemaVersion(db.db)
+	if err != nil {
+		return err
+	}
+
+	pending, err := pendingMigrations(steps, version)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range pending {
+		if err := db.applyMigration(step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMigration executa uma migração e registra sua versão na mesma transação
+func (db *sqliteDatabase) applyMigration(step Migration) error {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore rollback errors
+	}()
+
+	if err := step.Apply(tx); err != nil {
+		return fmt.Errorf("erro ao aplicar migração %d (%s): %v", step.Version, step.Description, err)
+	}
+
+	_, err = tx.Exec("INSERT INTO schema_version (version, description, applied_at) VALUES (?, ?, ?)",
+		step.Version, step.Description, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("erro ao registrar migração %d: %v", step.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar migração %d: %v", step.Version, err)
+	}
+	return nil
+}
+
+// schemaVersion retorna a maior versão aplicada ou 0 quando o banco ainda não tem controle de versão
+func schemaVersion(db *sql.DB) (int, error) {
+	var tables int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_version'").Scan(&tables)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao verificar versão do esquema: %v", err)
+	}
+	if tables == 0 {
+		return 0, nil
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("erro ao verificar versão do esquema: %v", err)
+	}
+	return int(version.Int64), nil
+}
+
+// pendingMigrations filtra as migrações posteriores à versão atual, recusando bancos de versões mais novas
+func pendingMigrations(steps []Migration, version int) ([]Migration, error) {
+	if len(steps) > 0 && version > steps[len(steps)-1].Version {
+		return nil, fmt.Errorf("banco na versão %d do esquema, mais nova que a suportada (%d): atualize o pr-champion",
+			version, steps[len(steps)-1].Version)
+	}
+
+	var pending []Migration
+	for _, step := range steps {
+		if step.Version > version {
+			pending = append(pending, step)
+		}
+	}
+	return pending, nil
+}
+
+// addColumnIfMissing adiciona uma coluna à tabela caso ela ainda não exista e indica se a coluna foi adicionada.
+// Bancos anteriores ao controle de versão podem já ter a coluna, por isso a verificação
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) (bool, error) {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, fmt.Errorf("erro ao inspecionar tabela %s: %v", table, err)
+	}
+
+	exists := false
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			rows.Close()
+			return false, fmt.Errorf("erro ao inspecionar tabela %s: %v", table, err)
+		}
+		if name == column {
+			exists = true
+		}
+	}
+	rows.Close()
+
+	if exists {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return false, fmt.Errorf("erro ao adicionar coluna %s.%s: %v", table, column, err)
+	}
+	return true, nil
+}
+
+// execAll executa os comandos SQL em sequência na transação
+func execAll(tx *sql.Tx, statements ...string) error {
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
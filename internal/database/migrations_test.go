@@ -0,0 +1,102 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrationsUpgradeLegacyDatabase(t *testing.T) {
+	path := newLegacyDatabase(t)
+
+	pending, err := PendingMigrations(path)
+	if err != nil {
+		t.Fatalf("PendingMigrations() error = %v", err)
+	}
+	if len(pending) != len(migrations) {
+		t.Fatalf("Expected all %d migrations pending on a legacy database, got %d", len(migrations), len(pending))
+	}
+
+	applied, err := MigrateDatabase(path)
+	if err != nil {
+		t.Fatalf("MigrateDatabase() error = %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("Expected %d migrations applied, got %d", len(migrations), len(applied))
+	}
+
+	// Reabrir o banco não reaplica nada
+	pending, err = PendingMigrations(path)
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("Expected no pending migrations after migrating, got %d (%v)", len(pending), err)
+	}
+	db, err := NewSQLiteDatabase(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	// Os dados existentes são preservados
+	comment, err := db.GetComment("org", "api", 100)
+	if err != nil || comment == nil || comment.Body != "lgtm" {
+		t.Errorf("Expected legacy comment to survive the migration, got %+v (%v)", comment, err)
+	}
+}
+
+func TestPendingMigrationsDoesNotCreateDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.db")
+
+	pending, err := PendingMigrations(path)
+	if err != nil {
+		t.Fatalf("PendingMigrations() error = %v", err)
+	}
+	if len(pending) != len(migrations) {
+		t.Errorf("Expected all migrations pending for a new database, got %d", len(pending))
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the dry run not to create %s", path)
+	}
+}
+
+func TestFailedMigrationIsRolledBack(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer sqlDB.Close()
+	db := &sqliteDatabase{db: sqlDB}
+
+	steps := []Migration{
+		{Version: 1, Description: "cria a tabela a", Apply: func(tx *sql.Tx) error {
+			return execAll(tx, "CREATE TABLE a (id INTEGER)")
+		}},
+		{Version: 2, Description: "falha no meio", Apply: func(tx *sql.Tx) error {
+			if err := execAll(tx, "CREATE TABLE b (id INTEGER)"); err != nil {
+				return err
+			}
+			return fmt.Errorf("falha simulada")
+		}},
+	}
+
+	if err := db.migrate(steps); err == nil {
+		t.Fatal("Expected migration error")
+	}
+
+	version, err := schemaVersion(sqlDB)
+	if err != nil || version != 1 {
+		t.Errorf("Expected schema version 1 after the failure, got %d (%v)", version, err)
+	}
+
+	var tables int
+	_ = sqlDB.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'b'").Scan(&tables)
+	if tables != 0 {
+		t.Error("Expected the failed migration to be rolled back")
+	}
+
+	// Um banco em versão mais nova que a conhecida é recusado
+	if _, err := pendingMigrations(steps[:1], 2); err == nil {
+		t.Error("Expected error for a database newer than the known migrations")
+	}
+}
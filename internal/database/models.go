@@ -29,6 +29,8 @@ type ReactionData struct {
 	ReactionType string    `json:"reaction_type"` // "issue_comment" ou "review_comment"
 	Content      string    `json:"content"`       // "+1", "-1", "heart", etc.
 	Username     string    `json:"username"`
+	ReactionID   int64     `json:"reaction_id"` // ID da reação no GitHub
+	CreatedAt    time.Time `json:"created_at"`  // Quando a reação foi feita (filtra reações após o merge)
 	CachedAt     time.Time `json:"cached_at"`
 }
 
@@ -41,6 +43,8 @@ type PRData struct {
 	Title                 string    `json:"title"`
 	Username              string    `json:"username"`
 	MergedAt              time.Time `json:"merged_at"`
+	BaseRef               string    `json:"base_ref"`                // Branch de destino do PR
+	UpdatedAt             time.Time `json:"updated_at"`              // Última atualização do PR no GitHub
 	HasComments           bool      `json:"has_comments"`            // Se tem comentários (issue ou review)
 	HasIssueComments      bool      `json:"has_issue_comments"`      // Se tem issue comments
 	HasReviewComments     bool      `json:"has_review_comments"`     // Se tem review comments
@@ -50,6 +54,75 @@ type PRData struct {
 	CachedAt              time.Time `json:"cached_at"`
 }
 
+// RepoSyncData guarda a marca d'água da sincronização de PRs mergeados de um repositório:
+// todos os PRs mergeados entre SyncedFrom e SyncedUntil estão salvos no banco
+type RepoSyncData struct {
+	RepoOwner    string    `json:"repo_owner"`
+	RepoName     string    `json:"repo_name"`
+	SyncedFrom   time.Time `json:"synced_from"`
+	SyncedUntil  time.Time `json:"synced_until"`
+	LastSyncedAt time.Time `json:"last_synced_at"`
+}
+
+// Covers indica se o intervalo sincronizado contém todo o período (from, until)
+func (s *RepoSyncData) Covers(from, until time.Time) bool {
+	return s != nil && !from.Before(s.SyncedFrom) && !until.After(s.SyncedUntil)
+}
+
+// CacheStats resume o conteúdo do banco usado como cache
+type CacheStats struct {
+	Repos         []*RepoCacheStats `json:"repos"`
+	Age           []*CacheAgeBucket `json:"age"`            // Distribuição de PRs e comentários pela idade do cache
+	StaleComments int               `json:"stale_comments"` // Comentários que serão buscados novamente na próxima execução
+	SizeBytes     int64             `json:"size_bytes"`
+	FreeBytes     int64             `json:"free_bytes"` // Espaço livre recuperável com VACUUM
+}
+
+// RepoCacheStats contabiliza as linhas em cache de um repositório
+type RepoCacheStats struct {
+	Host          string        `json:"host"`
+	RepoOwner     string        `json:"repo_owner"`
+	RepoName      string        `json:"repo_name"`
+	PRs           int           `json:"prs"`
+	Comments      int           `json:"comments"`
+	Reactions     int           `json:"reactions"`
+	StaleComments int           `json:"stale_comments"`
+	Sync          *RepoSyncData `json:"sync,omitempty"`
+}
+
+// CacheAgeBucket conta as linhas com idade de cache até MaxAge (zero = sem limite)
+type CacheAgeBucket struct {
+	Label    string        `json:"label"`
+	MaxAge   time.Duration `json:"max_age"`
+	PRs      int           `json:"prs"`
+	Comments int           `json:"comments"`
+}
+
+// CacheDeletion contabiliza as linhas removidas por prune ou invalidate (no invalidate, PRs conta os PRs invalidados)
+type CacheDeletion struct {
+	PRs       int64 `json:"prs"`
+	Comments  int64 `json:"comments"`
+	Reactions int64 `json:"reactions"`
+}
+
+// ToGithubPR converte um PRData de volta para github.PullRequest com os campos usados na análise
+func (pr *PRData) ToGithubPR() *github.PullRequest {
+	return &github.PullRequest{
+		Number:    github.Int(pr.PRNumber),
+		Title:     github.String(pr.Title),
+		User:      &github.User{Login: github.String(pr.Username)},
+		MergedAt:  &github.Timestamp{Time: pr.MergedAt},
+		UpdatedAt: &github.Timestamp{Time: pr.UpdatedAt},
+		Base: &github.PullRequestBranch{
+			Ref: github.String(pr.BaseRef),
+			Repo: &github.Repository{
+				Name:  github.String(pr.RepoName),
+				Owner: &github.User{Login: github.String(pr.RepoOwner)},
+			},
+		},
+	}
+}
+
 // CommentWithReactions representa um comentário com suas reações
 type CommentWithReactions struct {
 	Comment   *CommentData    `json:"comment"`
@@ -97,6 +170,8 @@ func FromGithubReaction(reaction *github.Reaction, commentID int64) *ReactionDat
 		ReactionType: "issue_comment",
 		Content:      reaction.GetContent(),
 		Username:     reaction.User.GetLogin(),
+		ReactionID:   reaction.GetID(),
+		CreatedAt:    reaction.GetCreatedAt().Time,
 		CachedAt:     time.Now(),
 	}
 }
@@ -108,6 +183,8 @@ func FromGithubReviewReaction(reaction *github.Reaction, commentID int64) *React
 		ReactionType: "review_comment",
 		Content:      reaction.GetContent(),
 		Username:     reaction.User.GetLogin(),
+		ReactionID:   reaction.GetID(),
+		CreatedAt:    reaction.GetCreatedAt().Time,
 		CachedAt:     time.Now(),
 	}
 }
@@ -121,6 +198,8 @@ func FromGithubPR(pr *github.PullRequest, repoOwner, repoName string) *PRData {
 		Title:                 pr.GetTitle(),
 		Username:              pr.User.GetLogin(),
 		MergedAt:              pr.MergedAt.Time,
+		BaseRef:               pr.GetBase().GetRef(),
+		UpdatedAt:             pr.GetUpdatedAt().Time,
 		HasComments:           false, // Será atualizado após verificação
 		HasIssueComments:      false, // Será atualizado após verificação
 		HasReviewComments:     false, // Será atualizado após verificação
@@ -5,13 +5,27 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
-// CommentDatabase interface para operações de banco de dados
+// DefaultHost identifica no cache os repositórios do github.com
+const DefaultHost = "github.com"
+
+// StalenessFunc indica se um comentário salvo em cachedAt, de um PR mergeado em mergedAt, será buscado
+// novamente na próxima execução (mergedAt zero quando o PR não está no cache)
+type StalenessFunc func(cachedAt, mergedAt time.Time) bool
+
+// CommentDatabase interface para operações de banco de dados.
+// PRs, comentários, reações e sincronizações são lidos e gravados no host da instância (ver WithHost);
+// as operações de administração do cache valem para todos os hosts
 type CommentDatabase interface {
+	// WithHost retorna uma visão do banco restrita aos repositórios do host informado.
+	// A visão compartilha a conexão do banco original, que é o único que deve ser fechado
+	WithHost(host string) CommentDatabase
+
 	// PRs
 	GetPR(repoOwner, repoName string, prNumber int) (*PRData, error)
 	SavePR(pr *PRData) error
@@ -20,15 +34,32 @@ type CommentDatabase interface {
 	// Comentários
 	GetComment(repoOwner, repoName string, commentID int64) (*CommentData, error)
 	SaveComment(comment *CommentData) error
+	SaveComments(repoOwner, repoName string, prNumber int, commentType string, comments []*CommentData) error
 	GetCommentsByPR(repoOwner, repoName string, prNumber int) ([]*CommentData, error)
 	GetCommentsByPRAndType(repoOwner, repoName string, prNumber int, commentType string) ([]*CommentData, error)
 	MarkReactionsChecked(commentID int64) error
 
+	// PRs mergeados e sincronização incremental
+	SaveMergedPRs(prs []*PRData) error
+	GetMergedPRs(repoOwner, repoName string, from, until time.Time) ([]*PRData, error)
+	GetRepoSync(repoOwner, repoName string) (*RepoSyncData, error)
+	SaveRepoSync(sync *RepoSyncData) error
+
+	// Branch padrão resolvida pela API, usada no modo offline
+	GetDefaultBranch(repoOwner, repoName string) (string, error)
+	SaveDefaultBranch(repoOwner, repoName, branch string) error
+
 	// Reações
 	GetReactions(commentID int64) ([]*ReactionData, error)
 	GetReactionsByType(commentID int64, reactionType string) ([]*ReactionData, error)
 	SaveReaction(reaction *ReactionData) error
-	SaveReactions(reactions []*ReactionData) error
+	SaveReactions(commentID int64, reactionType string, reactions []*ReactionData) error
+
+	// Administração do cache
+	Stats(isStale StalenessFunc) (*CacheStats, error)
+	PruneCache(host, repoOwner, repoName string, mergedBefore time.Time) (*CacheDeletion, error)
+	InvalidatePR(host, repoOwner, repoName string, prNumber int) (*CacheDeletion, error)
+	Vacuum() error
 
 	// Utilitários
 	ClearDatabase() error
@@ -36,16 +67,39 @@ type CommentDatabase interface {
 }
 
 type sqliteDatabase struct {
-	db *sql.DB
+	db   *sql.DB
+	host string // Host dos repositórios lidos e gravados
 }
 
-// NewSQLiteDatabase cria uma nova instância do banco SQLite
+// NewSQLiteDatabase cria uma nova instância do banco SQLite, aplicando as migrações pendentes
 func NewSQLiteDatabase(dbPath string) (CommentDatabase, error) {
 	// Cria o diretório se não existir
 	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
 		return nil, fmt.Errorf("erro ao criar diretório do banco: %v", err)
 	}
 
+	sqliteDB, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cria as tabelas ou atualiza o esquema de bancos existentes
+	if err := sqliteDB.migrate(migrations); err != nil {
+		sqliteDB.db.Close()
+		return nil, fmt.Errorf("erro ao migrar esquema do banco: %v", err)
+	}
+
+	return sqliteDB, nil
+}
+
+// OpenSQLiteDatabase abre um banco SQLite existente sem alterar o esquema; quem chama deve
+// garantir, com PendingMigrations, que o banco já está na versão atual
+func OpenSQLiteDatabase(dbPath string) (CommentDatabase, error) {
+	return openSQLite(dbPath)
+}
+
+// openSQLite abre a conexão com o banco, sem migrar
+func openSQLite(dbPath string) (*sqliteDatabase, error) {
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao abrir banco SQLite: %v", err)
@@ -53,103 +107,23 @@ func NewSQLiteDatabase(dbPath string) (CommentDatabase, error) {
 
 	// Testa a conexão
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("erro ao conectar com banco SQLite: %v", err)
 	}
 
-	sqliteDB := &sqliteDatabase{db: db}
+	// O SQLite aceita um único escritor por vez; uma única conexão serializa os acessos
+	// feitos pelos workers que buscam comentários em paralelo e evita erros "database is locked"
+	db.SetMaxOpenConns(1)
 
-	// Cria as tabelas se não existirem
-	if err := sqliteDB.createTables(); err != nil {
-		return nil, fmt.Errorf("erro ao criar tabelas: %v", err)
-	}
-
-	return sqliteDB, nil
+	return &sqliteDatabase{db: db, host: DefaultHost}, nil
 }
 
-// createTables cria as tabelas necessárias
-func (db *sqliteDatabase) createTables() error {
-	// Tabela de comentários
-	createCommentsTable := `
-	CREATE TABLE IF NOT EXISTS comments (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		repo_owner TEXT NOT NULL,
-		repo_name TEXT NOT NULL,
-		pr_number INTEGER NOT NULL,
-		comment_id INTEGER NOT NULL UNIQUE,
-		comment_type TEXT NOT NULL,
-		username TEXT NOT NULL,
-		body TEXT,
-		created_at DATETIME NOT NULL,
-		updated_at DATETIME NOT NULL,
-		cached_at DATETIME NOT NULL,
-		reactions_checked BOOLEAN DEFAULT FALSE,
-		UNIQUE(comment_id)
-	);`
-
-	// Tabela de PRs
-	createPRsTable := `
-	CREATE TABLE IF NOT EXISTS prs (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		repo_owner TEXT NOT NULL,
-		repo_name TEXT NOT NULL,
-		pr_number INTEGER NOT NULL,
-		title TEXT NOT NULL,
-		username TEXT NOT NULL,
-		merged_at DATETIME NOT NULL,
-		has_comments BOOLEAN DEFAULT FALSE,
-		has_issue_comments BOOLEAN DEFAULT FALSE,
-		has_review_comments BOOLEAN DEFAULT FALSE,
-		comments_checked BOOLEAN DEFAULT FALSE,
-		issue_comments_checked BOOLEAN DEFAULT FALSE,
-		review_comments_checked BOOLEAN DEFAULT FALSE,
-		cached_at DATETIME NOT NULL,
-		UNIQUE(repo_owner, repo_name, pr_number)
-	);`
-
-	// Tabela de reações
-	createReactionsTable := `
-	CREATE TABLE IF NOT EXISTS reactions (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		comment_id INTEGER NOT NULL,
-		reaction_type TEXT NOT NULL DEFAULT 'issue_comment',
-		content TEXT NOT NULL,
-		username TEXT NOT NULL,
-		cached_at DATETIME NOT NULL,
-		FOREIGN KEY(comment_id) REFERENCES comments(comment_id),
-		UNIQUE(comment_id, reaction_type, content, username)
-	);`
-
-	// Índices para melhor performance
-	createIndexes := []string{
-		`CREATE INDEX IF NOT EXISTS idx_comments_repo_pr ON comments(repo_owner, repo_name, pr_number);`,
-		`CREATE INDEX IF NOT EXISTS idx_comments_comment_id ON comments(comment_id);`,
-		`CREATE INDEX IF NOT EXISTS idx_reactions_comment_id ON reactions(comment_id);`,
-		`CREATE INDEX IF NOT EXISTS idx_comments_cached_at ON comments(cached_at);`,
-		`CREATE INDEX IF NOT EXISTS idx_prs_repo ON prs(repo_owner, repo_name);`,
-		`CREATE INDEX IF NOT EXISTS idx_prs_repo_pr ON prs(repo_owner, repo_name, pr_number);`,
-	}
-
-	// Executa criação das tabelas
-	if _, err := db.db.Exec(createCommentsTable); err != nil {
-		return fmt.Errorf("erro ao criar tabela comments: %v", err)
-	}
-
-	if _, err := db.db.Exec(createPRsTable); err != nil {
-		return fmt.Errorf("erro ao criar tabela prs: %v", err)
-	}
-
-	if _, err := db.db.Exec(createReactionsTable); err != nil {
-		return fmt.Errorf("erro ao criar tabela reactions: %v", err)
-	}
-
-	// Executa criação dos índices
-	for _, indexSQL := range createIndexes {
-		if _, err := db.db.Exec(indexSQL); err != nil {
-			return fmt.Errorf("erro ao criar índice: %v", err)
-		}
+// WithHost retorna uma visão do banco restrita aos repositórios do host informado
+func (db *sqliteDatabase) WithHost(host string) CommentDatabase {
+	if host == "" {
+		host = DefaultHost
 	}
-
-	return nil
+	return &sqliteDatabase{db: db.db, host: host}
 }
 
 // GetPR busca um PR pelo repositório e número
@@ -159,9 +133,9 @@ func (db *sqliteDatabase) GetPR(repoOwner, repoName string, prNumber int) (*PRDa
 		       has_comments, has_issue_comments, has_review_comments,
 		       comments_checked, issue_comments_checked, review_comments_checked, cached_at
 		FROM prs 
-		WHERE repo_owner = ? AND repo_name = ? AND pr_number = ?`
+		WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ?`
 
-	row := db.db.QueryRow(query, repoOwner, repoName, prNumber)
+	row := db.db.QueryRow(query, db.host, repoOwner, repoName, prNumber)
 
 	pr := &PRData{}
 	err := row.Scan(
@@ -191,22 +165,34 @@ func (db *sqliteDatabase) GetPR(repoOwner, repoName string, prNumber int) (*PRDa
 	return pr, nil
 }
 
+// savePRQuery insere um PR ou, se ele já existir, atualiza apenas seus metadados,
+// preservando as marcações de comentários já verificados
+const savePRQuery = `
+	INSERT INTO prs
+	(host, repo_owner, repo_name, pr_number, title, username, merged_at, base_ref, updated_at,
+	 has_comments, has_issue_comments, has_review_comments,
+	 comments_checked, issue_comments_checked, review_comments_checked, cached_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(host, repo_owner, repo_name, pr_number) DO UPDATE SET
+		title = excluded.title,
+		username = excluded.username,
+		merged_at = excluded.merged_at,
+		base_ref = excluded.base_ref,
+		updated_at = excluded.updated_at,
+		cached_at = excluded.cached_at`
+
 // SavePR salva um PR no banco
 func (db *sqliteDatabase) SavePR(pr *PRData) error {
-	query := `
-		INSERT OR REPLACE INTO prs 
-		(repo_owner, repo_name, pr_number, title, username, merged_at,
-		 has_comments, has_issue_comments, has_review_comments,
-		 comments_checked, issue_comments_checked, review_comments_checked, cached_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-
-	_, err := db.db.Exec(query,
+	_, err := db.db.Exec(savePRQuery,
+		db.host,
 		pr.RepoOwner,
 		pr.RepoName,
 		pr.PRNumber,
 		pr.Title,
 		pr.Username,
-		pr.MergedAt,
+		pr.MergedAt.UTC(),
+		pr.BaseRef,
+		pr.UpdatedAt.UTC(),
 		pr.HasComments,
 		pr.HasIssueComments,
 		pr.HasReviewComments,
@@ -235,11 +221,11 @@ func (db *sqliteDatabase) MarkPRCommentsChecked(repoOwner, repoName string, prNu
 	if existingPR == nil {
 		insertQuery := `
 			INSERT INTO prs 
-			(repo_owner, repo_name, pr_number, title, username, merged_at, cached_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?)`
+			(host, repo_owner, repo_name, pr_number, title, username, merged_at, cached_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 		now := time.Now()
-		_, err := db.db.Exec(insertQuery, repoOwner, repoName, prNumber, "", "", now, now)
+		_, err := db.db.Exec(insertQuery, db.host, repoOwner, repoName, prNumber, "", "", now, now)
 		if err != nil {
 			return fmt.Errorf("erro ao criar registro básico do PR: %v", err)
 		}
@@ -251,14 +237,14 @@ func (db *sqliteDatabase) MarkPRCommentsChecked(repoOwner, repoName string, prNu
 
 	switch commentType {
 	case "issue":
-		query = `UPDATE prs SET issue_comments_checked = TRUE, has_issue_comments = ? WHERE repo_owner = ? AND repo_name = ? AND pr_number = ?`
-		args = []interface{}{hasComments, repoOwner, repoName, prNumber}
+		query = `UPDATE prs SET issue_comments_checked = TRUE, has_issue_comments = ? WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ?`
+		args = []interface{}{hasComments, db.host, repoOwner, repoName, prNumber}
 	case "review":
-		query = `UPDATE prs SET review_comments_checked = TRUE, has_review_comments = ? WHERE repo_owner = ? AND repo_name = ? AND pr_number = ?`
-		args = []interface{}{hasComments, repoOwner, repoName, prNumber}
+		query = `UPDATE prs SET review_comments_checked = TRUE, has_review_comments = ? WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ?`
+		args = []interface{}{hasComments, db.host, repoOwner, repoName, prNumber}
 	default:
-		query = `UPDATE prs SET comments_checked = TRUE, has_comments = ? WHERE repo_owner = ? AND repo_name = ? AND pr_number = ?`
-		args = []interface{}{hasComments, repoOwner, repoName, prNumber}
+		query = `UPDATE prs SET comments_checked = TRUE, has_comments = ? WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ?`
+		args = []interface{}{hasComments, db.host, repoOwner, repoName, prNumber}
 	}
 
 	_, err = db.db.Exec(query, args...)
@@ -269,23 +255,23 @@ func (db *sqliteDatabase) MarkPRCommentsChecked(repoOwner, repoName string, prNu
 	// Atualiza has_comments se ambos os tipos foram verificados
 	updateGeneralQuery := `
 		UPDATE prs SET has_comments = (has_issue_comments OR has_review_comments)
-		WHERE repo_owner = ? AND repo_name = ? AND pr_number = ? 
+		WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ? 
 		AND issue_comments_checked = TRUE AND review_comments_checked = TRUE`
 
-	_, _ = db.db.Exec(updateGeneralQuery, repoOwner, repoName, prNumber)
+	_, _ = db.db.Exec(updateGeneralQuery, db.host, repoOwner, repoName, prNumber)
 
 	return nil
 }
 
-// GetComment busca um comentário pelo ID
+// GetComment busca um comentário pelo ID dentro do repositório informado
 func (db *sqliteDatabase) GetComment(repoOwner, repoName string, commentID int64) (*CommentData, error) {
 	query := `
 		SELECT id, repo_owner, repo_name, pr_number, comment_id, comment_type, 
 		       username, body, created_at, updated_at, cached_at, reactions_checked
 		FROM comments 
-		WHERE comment_id = ?`
+		WHERE host = ? AND repo_owner = ? AND repo_name = ? AND comment_id = ?`
 
-	row := db.db.QueryRow(query, commentID)
+	row := db.db.QueryRow(query, db.host, repoOwner, repoName, commentID)
 
 	comment := &CommentData{}
 	err := row.Scan(
@@ -317,10 +303,11 @@ func (db *sqliteDatabase) GetComment(repoOwner, repoName string, commentID int64
 func (db *sqliteDatabase) SaveComment(comment *CommentData) error {
 	query := `
 		INSERT OR REPLACE INTO comments 
-		(repo_owner, repo_name, pr_number, comment_id, comment_type, username, body, created_at, updated_at, cached_at, reactions_checked)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		(host, repo_owner, repo_name, pr_number, comment_id, comment_type, username, body, created_at, updated_at, cached_at, reactions_checked)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := db.db.Exec(query,
+		db.host,
 		comment.RepoOwner,
 		comment.RepoName,
 		comment.PRNumber,
@@ -341,16 +328,76 @@ func (db *sqliteDatabase) SaveComment(comment *CommentData) error {
 	return nil
 }
 
+// SaveComments substitui os comentários de um tipo em cache para o PR pelos comentários buscados.
+// Comentários removidos no GitHub deixam o cache junto com suas reações, e uma lista vazia limpa o tipo
+func (db *sqliteDatabase) SaveComments(repoOwner, repoName string, prNumber int, commentType string, comments []*CommentData) error {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore rollback errors
+	}()
+
+	// Reações primeiro (por causa da foreign key), depois os comentários
+	if _, err := tx.Exec(`
+		DELETE FROM reactions WHERE (host, comment_id) IN (
+			SELECT host, comment_id FROM comments
+			WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ? AND comment_type = ?)`,
+		db.host, repoOwner, repoName, prNumber, commentType); err != nil {
+		return fmt.Errorf("erro ao remover reações antigas: %v", err)
+	}
+	if _, err := tx.Exec("DELETE FROM comments WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ? AND comment_type = ?",
+		db.host, repoOwner, repoName, prNumber, commentType); err != nil {
+		return fmt.Errorf("erro ao remover comentários antigos: %v", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT OR REPLACE INTO comments 
+		(host, repo_owner, repo_name, pr_number, comment_id, comment_type, username, body, created_at, updated_at, cached_at, reactions_checked)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar statement: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, comment := range comments {
+		_, err := stmt.Exec(
+			db.host,
+			comment.RepoOwner,
+			comment.RepoName,
+			comment.PRNumber,
+			comment.CommentID,
+			comment.CommentType,
+			comment.Username,
+			comment.Body,
+			comment.CreatedAt,
+			comment.UpdatedAt,
+			comment.CachedAt,
+			comment.ReactionsChecked,
+		)
+		if err != nil {
+			return fmt.Errorf("erro ao salvar comentário: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %v", err)
+	}
+
+	return nil
+}
+
 // GetCommentsByPR busca todos os comentários de um PR
 func (db *sqliteDatabase) GetCommentsByPR(repoOwner, repoName string, prNumber int) ([]*CommentData, error) {
 	query := `
 		SELECT id, repo_owner, repo_name, pr_number, comment_id, comment_type, 
 		       username, body, created_at, updated_at, cached_at, reactions_checked
 		FROM comments 
-		WHERE repo_owner = ? AND repo_name = ? AND pr_number = ?
+		WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ?
 		ORDER BY created_at`
 
-	rows, err := db.db.Query(query, repoOwner, repoName, prNumber)
+	rows, err := db.db.Query(query, db.host, repoOwner, repoName, prNumber)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar comentários do PR: %v", err)
 	}
@@ -388,10 +435,10 @@ func (db *sqliteDatabase) GetCommentsByPRAndType(repoOwner, repoName string, prN
 		SELECT id, repo_owner, repo_name, pr_number, comment_id, comment_type, 
 		       username, body, created_at, updated_at, cached_at, reactions_checked
 		FROM comments 
-		WHERE repo_owner = ? AND repo_name = ? AND pr_number = ? AND comment_type = ?
+		WHERE host = ? AND repo_owner = ? AND repo_name = ? AND pr_number = ? AND comment_type = ?
 		ORDER BY created_at`
 
-	rows, err := db.db.Query(query, repoOwner, repoName, prNumber, commentType)
+	rows, err := db.db.Query(query, db.host, repoOwner, repoName, prNumber, commentType)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar comentários do PR por tipo: %v", err)
 	}
@@ -429,9 +476,9 @@ func (db *sqliteDatabase) GetCommentsByPRAndType(repoOwner, repoName string, prN
 
 // MarkReactionsChecked marca que as reações de um comentário foram verificadas
 func (db *sqliteDatabase) MarkReactionsChecked(commentID int64) error {
-	query := `UPDATE comments SET reactions_checked = TRUE WHERE comment_id = ?`
+	query := `UPDATE comments SET reactions_checked = TRUE WHERE host = ? AND comment_id = ?`
 
-	_, err := db.db.Exec(query, commentID)
+	_, err := db.db.Exec(query, db.host, commentID)
 	if err != nil {
 		return fmt.Errorf("erro ao marcar reações como verificadas: %v", err)
 	}
@@ -442,11 +489,11 @@ func (db *sqliteDatabase) MarkReactionsChecked(commentID int64) error {
 // GetReactions busca todas as reações de um comentário
 func (db *sqliteDatabase) GetReactions(commentID int64) ([]*ReactionData, error) {
 	query := `
-		SELECT id, comment_id, reaction_type, content, username, cached_at
+		SELECT id, comment_id, reaction_type, content, username, reaction_id, created_at, cached_at
 		FROM reactions 
-		WHERE comment_id = ?`
+		WHERE host = ? AND comment_id = ?`
 
-	rows, err := db.db.Query(query, commentID)
+	rows, err := db.db.Query(query, db.host, commentID)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar reações: %v", err)
 	}
@@ -455,17 +502,21 @@ func (db *sqliteDatabase) GetReactions(commentID int64) ([]*ReactionData, error)
 	var reactions []*ReactionData
 	for rows.Next() {
 		reaction := &ReactionData{}
+		var createdAt sql.NullTime
 		err := rows.Scan(
 			&reaction.ID,
 			&reaction.CommentID,
 			&reaction.ReactionType,
 			&reaction.Content,
 			&reaction.Username,
+			&reaction.ReactionID,
+			&createdAt,
 			&reaction.CachedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("erro ao escanear reação: %v", err)
 		}
+		reaction.CreatedAt = createdAt.Time
 		reactions = append(reactions, reaction)
 	}
 
@@ -475,11 +526,11 @@ func (db *sqliteDatabase) GetReactions(commentID int64) ([]*ReactionData, error)
 // GetReactionsByType busca reações de um comentário por tipo específico
 func (db *sqliteDatabase) GetReactionsByType(commentID int64, reactionType string) ([]*ReactionData, error) {
 	query := `
-		SELECT id, comment_id, reaction_type, content, username, cached_at
+		SELECT id, comment_id, reaction_type, content, username, reaction_id, created_at, cached_at
 		FROM reactions 
-		WHERE comment_id = ? AND reaction_type = ?`
+		WHERE host = ? AND comment_id = ? AND reaction_type = ?`
 
-	rows, err := db.db.Query(query, commentID, reactionType)
+	rows, err := db.db.Query(query, db.host, commentID, reactionType)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar reações por tipo: %v", err)
 	}
@@ -488,17 +539,21 @@ func (db *sqliteDatabase) GetReactionsByType(commentID int64, reactionType strin
 	var reactions []*ReactionData
 	for rows.Next() {
 		reaction := &ReactionData{}
+		var createdAt sql.NullTime
 		err := rows.Scan(
 			&reaction.ID,
 			&reaction.CommentID,
 			&reaction.ReactionType,
 			&reaction.Content,
 			&reaction.Username,
+			&reaction.ReactionID,
+			&createdAt,
 			&reaction.CachedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("erro ao escanear reação por tipo: %v", err)
 		}
+		reaction.CreatedAt = createdAt.Time
 		reactions = append(reactions, reaction)
 	}
 
@@ -509,14 +564,17 @@ func (db *sqliteDatabase) GetReactionsByType(commentID int64, reactionType strin
 func (db *sqliteDatabase) SaveReaction(reaction *ReactionData) error {
 	query := `
 		INSERT OR REPLACE INTO reactions 
-		(comment_id, reaction_type, content, username, cached_at)
-		VALUES (?, ?, ?, ?, ?)`
+		(host, comment_id, reaction_type, content, username, reaction_id, created_at, cached_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := db.db.Exec(query,
+		db.host,
 		reaction.CommentID,
 		reaction.ReactionType,
 		reaction.Content,
 		reaction.Username,
+		reaction.ReactionID,
+		reaction.CreatedAt.UTC(),
 		reaction.CachedAt,
 	)
 
@@ -527,12 +585,9 @@ func (db *sqliteDatabase) SaveReaction(reaction *ReactionData) error {
 	return nil
 }
 
-// SaveReactions salva múltiplas reações no banco
-func (db *sqliteDatabase) SaveReactions(reactions []*ReactionData) error {
-	if len(reactions) == 0 {
-		return nil
-	}
-
+// SaveReactions substitui as reações de um tipo em cache para o comentário pelas reações buscadas.
+// Reações removidas no GitHub deixam o cache, e uma lista vazia limpa as reações do comentário
+func (db *sqliteDatabase) SaveReactions(commentID int64, reactionType string, reactions []*ReactionData) error {
 	tx, err := db.db.Begin()
 	if err != nil {
 		return fmt.Errorf("erro ao iniciar transação: %v", err)
@@ -541,10 +596,15 @@ func (db *sqliteDatabase) SaveReactions(reactions []*ReactionData) error {
 		_ = tx.Rollback() // Ignore rollback errors
 	}()
 
+	if _, err := tx.Exec("DELETE FROM reactions WHERE host = ? AND comment_id = ? AND reaction_type = ?",
+		db.host, commentID, reactionType); err != nil {
+		return fmt.Errorf("erro ao remover reações antigas: %v", err)
+	}
+
 	query := `
 		INSERT OR REPLACE INTO reactions 
-		(comment_id, reaction_type, content, username, cached_at)
-		VALUES (?, ?, ?, ?, ?)`
+		(host, comment_id, reaction_type, content, username, reaction_id, created_at, cached_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	stmt, err := tx.Prepare(query)
 	if err != nil {
@@ -554,10 +614,13 @@ func (db *sqliteDatabase) SaveReactions(reactions []*ReactionData) error {
 
 	for _, reaction := range reactions {
 		_, err := stmt.Exec(
+			db.host,
 			reaction.CommentID,
 			reaction.ReactionType,
 			reaction.Content,
 			reaction.Username,
+			reaction.ReactionID,
+			reaction.CreatedAt.UTC(),
 			reaction.CachedAt,
 		)
 		if err != nil {
@@ -589,22 +652,490 @@ func (db *sqliteDatabase) ClearDatabase() error {
 		return fmt.Errorf("erro ao limpar tabela prs: %v", err)
 	}
 
+	// Remove as marcas d'água de sincronização (os PRs precisam ser buscados novamente)
+	if _, err := db.db.Exec("DELETE FROM repo_sync"); err != nil {
+		return fmt.Errorf("erro ao limpar tabela repo_sync: %v", err)
+	}
+
+	// Remove as branches padrão resolvidas
+	if _, err := db.db.Exec("DELETE FROM repo_default_branch"); err != nil {
+		return fmt.Errorf("erro ao limpar tabela repo_default_branch: %v", err)
+	}
+
 	// Reset dos auto-increment
 	if _, err := db.db.Exec("DELETE FROM sqlite_sequence WHERE name IN ('comments', 'reactions', 'prs')"); err != nil {
 		// Não é um erro fatal se a tabela sqlite_sequence não existir
-		fmt.Printf("⚠️  Aviso: Não foi possível resetar sequências: %v\n", err)
+		fmt.Fprintf(os.Stderr, "⚠️  Aviso: Não foi possível resetar sequências: %v\n", err)
 	}
 
 	return nil
 }
 
-// Close fecha a conexão com o banco
-func (db *sqliteDatabase) Close() error {
-	return db.db.Close()
+// SaveMergedPRs salva uma lista de PRs mergeados em uma única transação
+func (db *sqliteDatabase) SaveMergedPRs(prs []*PRData) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore rollback errors
+	}()
+
+	stmt, err := tx.Prepare(savePRQuery)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar statement: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, pr := range prs {
+		_, err := stmt.Exec(
+			db.host,
+			pr.RepoOwner,
+			pr.RepoName,
+			pr.PRNumber,
+			pr.Title,
+			pr.Username,
+			pr.MergedAt.UTC(),
+			pr.BaseRef,
+			pr.UpdatedAt.UTC(),
+			pr.HasComments,
+			pr.HasIssueComments,
+			pr.HasReviewComments,
+			pr.CommentsChecked,
+			pr.IssueCommentsChecked,
+			pr.ReviewCommentsChecked,
+			pr.CachedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("erro ao salvar PR #%d: %v", pr.PRNumber, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %v", err)
+	}
+
+	return nil
 }
 
-// IsCommentStale verifica se um comentário em cache está desatualizado (mais de 7 dias)
-func (db *sqliteDatabase) IsCommentStale(comment *CommentData) bool {
-	cacheDuration := 7 * 24 * time.Hour // 7 dias
-	return time.Since(comment.CachedAt) > cacheDuration
+// GetMergedPRs busca os PRs de um repositório mergeados no intervalo aberto (from, until)
+func (db *sqliteDatabase) GetMergedPRs(repoOwner, repoName string, from, until time.Time) ([]*PRData, error) {
+	// Registros criados apenas para marcar comentários verificados não têm título e são ignorados
+	query := `
+		SELECT id, repo_owner, repo_name, pr_number, title, username, merged_at, base_ref, updated_at,
+		       has_comments, has_issue_comments, has_review_comments,
+		       comments_checked, issue_comments_checked, review_comments_checked, cached_at
+		FROM prs
+		WHERE host = ? AND repo_owner = ? AND repo_name = ? AND title != '' AND merged_at > ? AND merged_at < ?
+		ORDER BY merged_at DESC`
+
+	rows, err := db.db.Query(query, db.host, repoOwner, repoName, from.UTC(), until.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar PRs mergeados: %v", err)
+	}
+	defer rows.Close()
+
+	var prs []*PRData
+	for rows.Next() {
+		pr := &PRData{}
+		var updatedAt sql.NullTime
+		err := rows.Scan(
+			&pr.ID,
+			&pr.RepoOwner,
+			&pr.RepoName,
+			&pr.PRNumber,
+			&pr.Title,
+			&pr.Username,
+			&pr.MergedAt,
+			&pr.BaseRef,
+			&updatedAt,
+			&pr.HasComments,
+			&pr.HasIssueComments,
+			&pr.HasReviewComments,
+			&pr.CommentsChecked,
+			&pr.IssueCommentsChecked,
+			&pr.ReviewCommentsChecked,
+			&pr.CachedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler PR mergeado: %v", err)
+		}
+		pr.UpdatedAt = updatedAt.Time
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
+// GetRepoSync busca a marca d'água de sincronização de um repositório
+func (db *sqliteDatabase) GetRepoSync(repoOwner, repoName string) (*RepoSyncData, error) {
+	query := `
+		SELECT repo_owner, repo_name, synced_from, synced_until, last_synced_at
+		FROM repo_sync
+		WHERE host = ? AND repo_owner = ? AND repo_name = ?`
+
+	sync := &RepoSyncData{}
+	err := db.db.QueryRow(query, db.host, repoOwner, repoName).Scan(
+		&sync.RepoOwner,
+		&sync.RepoName,
+		&sync.SyncedFrom,
+		&sync.SyncedUntil,
+		&sync.LastSyncedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Repositório nunca sincronizado
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar sincronização do repositório: %v", err)
+	}
+
+	return sync, nil
+}
+
+// SaveRepoSync salva a marca d'água de sincronização de um repositório
+func (db *sqliteDatabase) SaveRepoSync(sync *RepoSyncData) error {
+	query := `
+		INSERT OR REPLACE INTO repo_sync (host, repo_owner, repo_name, synced_from, synced_until, last_synced_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := db.db.Exec(query,
+		db.host,
+		sync.RepoOwner,
+		sync.RepoName,
+		sync.SyncedFrom.UTC(),
+		sync.SyncedUntil.UTC(),
+		sync.LastSyncedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao salvar sincronização do repositório: %v", err)
+	}
+
+	return nil
+}
+
+// GetDefaultBranch busca a branch padrão salva de um repositório ("" se nunca foi resolvida)
+func (db *sqliteDatabase) GetDefaultBranch(repoOwner, repoName string) (string, error) {
+	var branch string
+	err := db.db.QueryRow(
+		"SELECT default_branch FROM repo_default_branch WHERE host = ? AND repo_owner = ? AND repo_name = ?",
+		db.host, repoOwner, repoName,
+	).Scan(&branch)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar branch padrão do repositório: %v", err)
+	}
+	return branch, nil
+}
+
+// SaveDefaultBranch salva a branch padrão resolvida de um repositório
+func (db *sqliteDatabase) SaveDefaultBranch(repoOwner, repoName, branch string) error {
+	query := `
+		INSERT OR REPLACE INTO repo_default_branch (host, repo_owner, repo_name, default_branch, resolved_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	if _, err := db.db.Exec(query, db.host, repoOwner, repoName, branch, time.Now()); err != nil {
+		return fmt.Errorf("erro ao salvar branch padrão do repositório: %v", err)
+	}
+	return nil
+}
+
+// newCacheAgeBuckets retorna as faixas de idade usadas em Stats, da mais recente para a mais antiga
+func newCacheAgeBuckets() []*CacheAgeBucket {
+	day := 24 * time.Hour
+	return []*CacheAgeBucket{
+		{Label: "até 1 dia", MaxAge: day},
+		{Label: "1 a 7 dias", MaxAge: 7 * day},
+		{Label: "7 a 30 dias", MaxAge: 30 * day},
+		{Label: "30 a 90 dias", MaxAge: 90 * day},
+		{Label: "mais de 90 dias"},
+	}
+}
+
+// ageBucket retorna a faixa correspondente à idade informada
+func ageBucket(buckets []*CacheAgeBucket, age time.Duration) *CacheAgeBucket {
+	for _, bucket := range buckets {
+		if bucket.MaxAge == 0 || age <= bucket.MaxAge {
+			return bucket
+		}
+	}
+	return buckets[len(buckets)-1]
+}
+
+// Stats contabiliza as linhas em cache por repositório (de todos os hosts), a idade do cache e o
+// tamanho do banco. Comentários para os quais isStale é verdadeiro são contados como desatualizados
+func (db *sqliteDatabase) Stats(isStale StalenessFunc) (*CacheStats, error) {
+	now := time.Now()
+	stats := &CacheStats{Age: newCacheAgeBuckets()}
+	repos := make(map[string]*RepoCacheStats)
+
+	repoStats := func(host, owner, name string) *RepoCacheStats {
+		key := host + "/" + owner + "/" + name
+		if repos[key] == nil {
+			repos[key] = &RepoCacheStats{Host: host, RepoOwner: owner, RepoName: name}
+		}
+		return repos[key]
+	}
+
+	// PRs: contagem por repositório e idade do cache
+	rows, err := db.db.Query("SELECT host, repo_owner, repo_name, cached_at FROM prs")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contabilizar tabela prs: %v", err)
+	}
+	for rows.Next() {
+		var host, owner, name string
+		var cachedAt time.Time
+		if err := rows.Scan(&host, &owner, &name, &cachedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao contabilizar tabela prs: %v", err)
+		}
+		repoStats(host, owner, name).PRs++
+		ageBucket(stats.Age, now.Sub(cachedAt)).PRs++
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("erro ao contabilizar tabela prs: %v", err)
+	}
+	rows.Close()
+
+	// Comentários: contagem, idade e validade pela data de merge do PR
+	rows, err = db.db.Query(`
+		SELECT c.host, c.repo_owner, c.repo_name, c.cached_at, p.merged_at
+		FROM comments c
+		LEFT JOIN prs p ON p.host = c.host AND p.repo_owner = c.repo_owner
+			AND p.repo_name = c.repo_name AND p.pr_number = c.pr_number`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contabilizar tabela comments: %v", err)
+	}
+	for rows.Next() {
+		var host, owner, name string
+		var cachedAt time.Time
+		var mergedAt sql.NullTime
+		if err := rows.Scan(&host, &owner, &name, &cachedAt, &mergedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao contabilizar tabela comments: %v", err)
+		}
+
+		repo := repoStats(host, owner, name)
+		repo.Comments++
+		ageBucket(stats.Age, now.Sub(cachedAt)).Comments++
+		if isStale(cachedAt, mergedAt.Time) {
+			repo.StaleComments++
+			stats.StaleComments++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("erro ao contabilizar tabela comments: %v", err)
+	}
+	rows.Close()
+
+	// Reações são associadas ao repositório pelo comentário
+	rows, err = db.db.Query(`
+		SELECT c.host, c.repo_owner, c.repo_name, COUNT(*)
+		FROM reactions r
+		JOIN comments c ON c.host = r.host AND c.comment_id = r.comment_id
+		GROUP BY c.host, c.repo_owner, c.repo_name`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao contabilizar reações: %v", err)
+	}
+	for rows.Next() {
+		var host, owner, name string
+		var count int
+		if err := rows.Scan(&host, &owner, &name, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao contabilizar reações: %v", err)
+		}
+		repoStats(host, owner, name).Reactions = count
+	}
+	rows.Close()
+
+	// Marcas d'água da sincronização de PRs
+	rows, err = db.db.Query("SELECT host, repo_owner, repo_name, synced_from, synced_until, last_synced_at FROM repo_sync")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar sincronizações: %v", err)
+	}
+	for rows.Next() {
+		var host string
+		sync := &RepoSyncData{}
+		if err := rows.Scan(&host, &sync.RepoOwner, &sync.RepoName, &sync.SyncedFrom, &sync.SyncedUntil, &sync.LastSyncedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("erro ao buscar sincronizações: %v", err)
+		}
+		repoStats(host, sync.RepoOwner, sync.RepoName).Sync = sync
+	}
+	rows.Close()
+
+	for _, repo := range repos {
+		stats.Repos = append(stats.Repos, repo)
+	}
+	sort.Slice(stats.Repos, func(i, j int) bool {
+		if stats.Repos[i].Host != stats.Repos[j].Host {
+			return stats.Repos[i].Host < stats.Repos[j].Host
+		}
+		if stats.Repos[i].RepoOwner != stats.Repos[j].RepoOwner {
+			return stats.Repos[i].RepoOwner < stats.Repos[j].RepoOwner
+		}
+		return stats.Repos[i].RepoName < stats.Repos[j].RepoName
+	})
+
+	// Tamanho do arquivo e espaço livre (recuperável com VACUUM)
+	var pageCount, pageSize, freePages int64
+	if err := db.db.QueryRow("PRAGMA page_count").Scan(&pageCount); err != nil {
+		return nil, fmt.Errorf("erro ao calcular tamanho do banco: %v", err)
+	}
+	if err := db.db.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		return nil, fmt.Errorf("erro ao calcular tamanho do banco: %v", err)
+	}
+	if err := db.db.QueryRow("PRAGMA freelist_count").Scan(&freePages); err != nil {
+		return nil, fmt.Errorf("erro ao calcular tamanho do banco: %v", err)
+	}
+	stats.SizeBytes = pageCount * pageSize
+	stats.FreeBytes = freePages * pageSize
+
+	return stats, nil
+}
+
+// PruneCache remove os PRs mergeados antes de mergedBefore, junto com seus comentários e reações.
+// Com repoOwner e repoName vazios, todos os repositórios são considerados; com host vazio, o repositório
+// informado é removido de todos os hosts em que estiver no cache. As marcas d'água de
+// sincronização são ajustadas para que os períodos removidos sejam buscados novamente na API
+func (db *sqliteDatabase) PruneCache(host, repoOwner, repoName string, mergedBefore time.Time) (*CacheDeletion, error) {
+	cutoff := mergedBefore.UTC()
+
+	prFilter := "p.merged_at < ?"
+	prArgs := []interface{}{cutoff}
+	repoFilter := ""
+	var repoArgs []interface{}
+	if host != "" {
+		prFilter += " AND p.host = ?"
+		prArgs = append(prArgs, host)
+		repoFilter += " AND host = ?"
+		repoArgs = append(repoArgs, host)
+	}
+	if repoOwner != "" {
+		prFilter += " AND p.repo_owner = ? AND p.repo_name = ?"
+		prArgs = append(prArgs, repoOwner, repoName)
+		repoFilter += " AND repo_owner = ? AND repo_name = ?"
+		repoArgs = append(repoArgs, repoOwner, repoName)
+	}
+	prunedPRs := "SELECT p.host, p.repo_owner, p.repo_name, p.pr_number FROM prs p WHERE " + prFilter
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore rollback errors
+	}()
+
+	deletion := &CacheDeletion{}
+
+	// Reações primeiro (por causa da foreign key), depois comentários e PRs
+	result, err := tx.Exec(`
+		DELETE FROM reactions WHERE (host, comment_id) IN (
+			SELECT host, comment_id FROM comments
+			WHERE (host, repo_owner, repo_name, pr_number) IN (`+prunedPRs+`))`, prArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover reações: %v", err)
+	}
+	deletion.Reactions, _ = result.RowsAffected()
+
+	result, err = tx.Exec(`DELETE FROM comments WHERE (host, repo_owner, repo_name, pr_number) IN (`+prunedPRs+`)`, prArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover comentários: %v", err)
+	}
+	deletion.Comments, _ = result.RowsAffected()
+
+	result, err = tx.Exec(`DELETE FROM prs WHERE (host, repo_owner, repo_name, pr_number) IN (`+prunedPRs+`)`, prArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover PRs: %v", err)
+	}
+	deletion.PRs, _ = result.RowsAffected()
+
+	// O intervalo sincronizado passa a começar no corte; sincronizações inteiramente anteriores são descartadas
+	if _, err := tx.Exec("DELETE FROM repo_sync WHERE synced_until <= ?"+repoFilter, append([]interface{}{cutoff}, repoArgs...)...); err != nil {
+		return nil, fmt.Errorf("erro ao ajustar sincronização: %v", err)
+	}
+	if _, err := tx.Exec("UPDATE repo_sync SET synced_from = ? WHERE synced_from < ?"+repoFilter, append([]interface{}{cutoff, cutoff}, repoArgs...)...); err != nil {
+		return nil, fmt.Errorf("erro ao ajustar sincronização: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar transação: %v", err)
+	}
+
+	return deletion, nil
+}
+
+// InvalidatePR descarta os comentários e reações em cache de um PR e marca seus comentários para
+// nova verificação no host informado ou, com host vazio, em todos os hosts. O PR continua no banco,
+// então a lista de PRs mergeados segue válida; CacheDeletion.PRs conta os PRs invalidados
+func (db *sqliteDatabase) InvalidatePR(host, repoOwner, repoName string, prNumber int) (*CacheDeletion, error) {
+	filter := "repo_owner = ? AND repo_name = ? AND pr_number = ?"
+	args := []interface{}{repoOwner, repoName, prNumber}
+	if host != "" {
+		filter += " AND host = ?"
+		args = append(args, host)
+	}
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore rollback errors
+	}()
+
+	deletion := &CacheDeletion{}
+
+	result, err := tx.Exec(`
+		DELETE FROM reactions WHERE (host, comment_id) IN (
+			SELECT host, comment_id FROM comments WHERE `+filter+`)`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover reações: %v", err)
+	}
+	deletion.Reactions, _ = result.RowsAffected()
+
+	result, err = tx.Exec("DELETE FROM comments WHERE "+filter, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover comentários: %v", err)
+	}
+	deletion.Comments, _ = result.RowsAffected()
+
+	result, err = tx.Exec(`
+		UPDATE prs SET
+			has_comments = FALSE, has_issue_comments = FALSE, has_review_comments = FALSE,
+			comments_checked = FALSE, issue_comments_checked = FALSE, review_comments_checked = FALSE
+		WHERE `+filter, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao invalidar PR #%d: %v", prNumber, err)
+	}
+	deletion.PRs, _ = result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao confirmar transação: %v", err)
+	}
+
+	return deletion, nil
+}
+
+// Vacuum reconstrói o arquivo do banco, devolvendo ao sistema o espaço das linhas removidas
+func (db *sqliteDatabase) Vacuum() error {
+	if _, err := db.db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("erro ao compactar banco: %v", err)
+	}
+	return nil
+}
+
+// Close fecha a conexão com o banco (compartilhada com as visões criadas por WithHost)
+func (db *sqliteDatabase) Close() error {
+	return db.db.Close()
 }
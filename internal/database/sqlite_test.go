@@ -0,0 +1,425 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// legacySchema é o esquema criado pelas versões anteriores ao armazenamento das datas das reações
+const legacySchema = `
+CREATE TABLE comments (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	repo_owner TEXT NOT NULL,
+	repo_name TEXT NOT NULL,
+	pr_number INTEGER NOT NULL,
+	comment_id INTEGER NOT NULL UNIQUE,
+	comment_type TEXT NOT NULL,
+	username TEXT NOT NULL,
+	body TEXT,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	cached_at DATETIME NOT NULL,
+	reactions_checked BOOLEAN DEFAULT FALSE,
+	UNIQUE(comment_id)
+);
+CREATE TABLE prs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	repo_owner TEXT NOT NULL,
+	repo_name TEXT NOT NULL,
+	pr_number INTEGER NOT NULL,
+	title TEXT NOT NULL,
+	username TEXT NOT NULL,
+	merged_at DATETIME NOT NULL,
+	has_comments BOOLEAN DEFAULT FALSE,
+	has_issue_comments BOOLEAN DEFAULT FALSE,
+	has_review_comments BOOLEAN DEFAULT FALSE,
+	comments_checked BOOLEAN DEFAULT FALSE,
+	issue_comments_checked BOOLEAN DEFAULT FALSE,
+	review_comments_checked BOOLEAN DEFAULT FALSE,
+	cached_at DATETIME NOT NULL,
+	UNIQUE(repo_owner, repo_name, pr_number)
+);
+CREATE TABLE reactions (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	comment_id INTEGER NOT NULL,
+	reaction_type TEXT NOT NULL DEFAULT 'issue_comment',
+	content TEXT NOT NULL,
+	username TEXT NOT NULL,
+	cached_at DATETIME NOT NULL,
+	FOREIGN KEY(comment_id) REFERENCES comments(comment_id),
+	UNIQUE(comment_id, reaction_type, content, username)
+);
+INSERT INTO comments (repo_owner, repo_name, pr_number, comment_id, comment_type, username, body, created_at, updated_at, cached_at, reactions_checked)
+VALUES ('org', 'api', 1, 100, 'issue', 'bob', 'lgtm', '2026-01-05 10:00:00', '2026-01-05 10:00:00', '2026-01-05 10:00:00', TRUE);
+INSERT INTO reactions (comment_id, reaction_type, content, username, cached_at)
+VALUES (100, 'issue_comment', '+1', 'carol', '2026-01-05 11:00:00');
+`
+
+// newLegacyDatabase cria um arquivo SQLite com o esquema antigo e devolve o caminho
+func newLegacyDatabase(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "legacy.db")
+	legacy, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer legacy.Close()
+
+	if _, err := legacy.Exec(legacySchema); err != nil {
+		t.Fatalf("legacy schema error = %v", err)
+	}
+	return path
+}
+
+func TestUpgradeResetsReactionsWithoutTimestamps(t *testing.T) {
+	db, err := NewSQLiteDatabase(newLegacyDatabase(t))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	comment, err := db.GetComment("org", "api", 100)
+	if err != nil || comment == nil {
+		t.Fatalf("GetComment() = %v, %v", comment, err)
+	}
+	if comment.ReactionsChecked {
+		t.Error("Expected reactions to be checked again after the upgrade")
+	}
+
+	reactions, err := db.GetReactions(100)
+	if err != nil {
+		t.Fatalf("GetReactions() error = %v", err)
+	}
+	if len(reactions) != 0 {
+		t.Errorf("Expected reactions without created_at to be discarded, got %d", len(reactions))
+	}
+}
+
+func TestReactionsRoundTripIDAndCreatedAt(t *testing.T) {
+	db, err := NewSQLiteDatabase(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	createdAt := time.Date(2026, 1, 5, 11, 30, 15, 0, time.FixedZone("BRT", -3*3600))
+	err = db.SaveReactions(100, "review_comment", []*ReactionData{{
+		CommentID:    100,
+		ReactionType: "review_comment",
+		Content:      "heart",
+		Username:     "carol",
+		ReactionID:   555,
+		CreatedAt:    createdAt,
+		CachedAt:     time.Now(),
+	}})
+	if err != nil {
+		t.Fatalf("SaveReactions() error = %v", err)
+	}
+
+	reactions, err := db.GetReactionsByType(100, "review_comment")
+	if err != nil || len(reactions) != 1 {
+		t.Fatalf("GetReactionsByType() = %v, %v", reactions, err)
+	}
+	if reactions[0].ReactionID != 555 || !reactions[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("Expected reaction 555 created at %v, got %d at %v", createdAt, reactions[0].ReactionID, reactions[0].CreatedAt)
+	}
+}
+
+func TestSaveReactionsReplacesRefetchedSet(t *testing.T) {
+	db, err := NewSQLiteDatabase(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	reaction := func(id int64, username string) *ReactionData {
+		return &ReactionData{CommentID: 100, ReactionType: "issue_comment", Content: "+1", Username: username, ReactionID: id, CreatedAt: now, CachedAt: now}
+	}
+	if err := db.SaveReactions(100, "review_comment", []*ReactionData{{CommentID: 100, ReactionType: "review_comment", Content: "heart", Username: "erin", CreatedAt: now, CachedAt: now}}); err != nil {
+		t.Fatalf("SaveReactions() error = %v", err)
+	}
+
+	// Cada nova busca substitui as reações do tipo; reações removidas no GitHub deixam o cache
+	for _, fetched := range [][]*ReactionData{{reaction(1, "alice"), reaction(2, "bob")}, {reaction(1, "alice")}, {}} {
+		if err := db.SaveReactions(100, "issue_comment", fetched); err != nil {
+			t.Fatalf("SaveReactions() error = %v", err)
+		}
+		reactions, err := db.GetReactionsByType(100, "issue_comment")
+		if err != nil || len(reactions) != len(fetched) {
+			t.Errorf("Expected %d cached reactions after refetch, got %d (%v)", len(fetched), len(reactions), err)
+		}
+	}
+
+	if reactions, _ := db.GetReactionsByType(100, "review_comment"); len(reactions) != 1 {
+		t.Errorf("Expected review comment reactions to be untouched, got %d", len(reactions))
+	}
+}
+
+func TestSaveCommentsReplacesPRCommentsOfType(t *testing.T) {
+	db := seedCacheDatabase(t)
+	now := time.Now()
+
+	// org/api#2 tinha o comentário 101 (com reação); a nova busca traz apenas o 200
+	err := db.SaveComments("org", "api", 2, "issue", []*CommentData{{
+		RepoOwner: "org", RepoName: "api", PRNumber: 2, CommentID: 200, CommentType: "issue",
+		Username: "erin", Body: "nit", CreatedAt: now, UpdatedAt: now, CachedAt: now,
+	}})
+	if err != nil {
+		t.Fatalf("SaveComments() error = %v", err)
+	}
+
+	comments, err := db.GetCommentsByPR("org", "api", 2)
+	if err != nil || len(comments) != 1 || comments[0].CommentID != 200 {
+		t.Errorf("Expected only comment 200 cached, got %v (%v)", comments, err)
+	}
+	if reactions, _ := db.GetReactions(101); len(reactions) != 0 {
+		t.Errorf("Expected reactions of the replaced comment to be removed, got %d", len(reactions))
+	}
+	if comments, _ := db.GetCommentsByPR("org", "api", 1); len(comments) != 1 {
+		t.Errorf("Expected other PRs to be untouched, got %d comments", len(comments))
+	}
+
+	if err := db.SaveComments("org", "api", 2, "issue", nil); err != nil {
+		t.Fatalf("SaveComments() error = %v", err)
+	}
+	if comments, _ := db.GetCommentsByPR("org", "api", 2); len(comments) != 0 {
+		t.Errorf("Expected an empty refetch to clear the PR comments, got %d", len(comments))
+	}
+}
+
+// seedCacheDatabase salva dois PRs de org/api (um antigo e um recente) e um de org/web, cada um com um comentário e uma reação
+func seedCacheDatabase(t *testing.T) CommentDatabase {
+	t.Helper()
+
+	db, err := NewSQLiteDatabase(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	now := time.Now()
+	prs := []*PRData{
+		{RepoOwner: "org", RepoName: "api", PRNumber: 1, Title: "old", Username: "alice", MergedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), CachedAt: now.AddDate(0, 0, -40)},
+		{RepoOwner: "org", RepoName: "api", PRNumber: 2, Title: "new", Username: "alice", MergedAt: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), CachedAt: now},
+		{RepoOwner: "org", RepoName: "web", PRNumber: 3, Title: "web", Username: "bob", MergedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), CachedAt: now},
+	}
+	if err := db.SaveMergedPRs(prs); err != nil {
+		t.Fatalf("SaveMergedPRs() error = %v", err)
+	}
+
+	for i, pr := range prs {
+		commentID := int64(100 + i)
+		err := db.SaveComment(&CommentData{
+			RepoOwner: pr.RepoOwner, RepoName: pr.RepoName, PRNumber: pr.PRNumber,
+			CommentID: commentID, CommentType: "issue", Username: "carol", Body: "lgtm",
+			CreatedAt: pr.MergedAt, UpdatedAt: pr.MergedAt, CachedAt: pr.CachedAt,
+		})
+		if err != nil {
+			t.Fatalf("SaveComment() error = %v", err)
+		}
+		err = db.SaveReactions(commentID, "issue_comment", []*ReactionData{{
+			CommentID: commentID, ReactionType: "issue_comment", Content: "+1", Username: "dave",
+			CreatedAt: pr.MergedAt, CachedAt: pr.CachedAt,
+		}})
+		if err != nil {
+			t.Fatalf("SaveReactions() error = %v", err)
+		}
+		if err := db.MarkPRCommentsChecked(pr.RepoOwner, pr.RepoName, pr.PRNumber, "issue", true); err != nil {
+			t.Fatalf("MarkPRCommentsChecked() error = %v", err)
+		}
+	}
+
+	err = db.SaveRepoSync(&RepoSyncData{
+		RepoOwner: "org", RepoName: "api",
+		SyncedFrom:   time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		SyncedUntil:  time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		LastSyncedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("SaveRepoSync() error = %v", err)
+	}
+	return db
+}
+
+// olderThan considera desatualizados os comentários em cache há mais tempo que o informado
+func olderThan(ttl time.Duration) StalenessFunc {
+	return func(cachedAt, mergedAt time.Time) bool {
+		return time.Since(cachedAt) > ttl
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	db := seedCacheDatabase(t)
+
+	stats, err := db.Stats(olderThan(7 * 24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+
+	if len(stats.Repos) != 2 || stats.Repos[0].RepoName != "api" || stats.Repos[1].RepoName != "web" {
+		t.Fatalf("Expected stats for org/api and org/web, got %+v", stats.Repos)
+	}
+	api := stats.Repos[0]
+	if api.PRs != 2 || api.Comments != 2 || api.Reactions != 2 || api.StaleComments != 1 || api.Sync == nil {
+		t.Errorf("Unexpected org/api stats: %+v", api)
+	}
+	if stats.StaleComments != 1 {
+		t.Errorf("Expected 1 stale comment, got %d", stats.StaleComments)
+	}
+	if recent, old := stats.Age[0], stats.Age[3]; recent.PRs != 2 || recent.Comments != 2 || old.PRs != 1 || old.Comments != 1 {
+		t.Errorf("Unexpected age distribution: recent %+v, 30-90 days %+v", recent, old)
+	}
+	if stats.SizeBytes <= 0 {
+		t.Errorf("Expected database size, got %d", stats.SizeBytes)
+	}
+
+	// A validade recebe a data de merge do PR de cada comentário
+	cutoff := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	stats, err = db.Stats(func(cachedAt, mergedAt time.Time) bool { return mergedAt.Before(cutoff) })
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.StaleComments != 2 {
+		t.Errorf("Expected the comments of the two PRs merged before %v to be stale, got %d", cutoff, stats.StaleComments)
+	}
+}
+
+func TestPruneCacheRemovesOldPRsAndAdjustsSync(t *testing.T) {
+	db := seedCacheDatabase(t)
+	cutoff := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	deletion, err := db.PruneCache("", "org", "api", cutoff)
+	if err != nil {
+		t.Fatalf("PruneCache() error = %v", err)
+	}
+	if *deletion != (CacheDeletion{PRs: 1, Comments: 1, Reactions: 1}) {
+		t.Errorf("Expected one PR, comment and reaction removed, got %+v", deletion)
+	}
+
+	if pr, _ := db.GetPR("org", "api", 1); pr != nil {
+		t.Error("Expected old org/api PR to be pruned")
+	}
+	if pr, _ := db.GetPR("org", "web", 3); pr == nil {
+		t.Error("Expected org/web to be untouched")
+	}
+
+	// O período removido deixa de constar como sincronizado
+	sync, err := db.GetRepoSync("org", "api")
+	if err != nil || sync == nil {
+		t.Fatalf("GetRepoSync() = %v, %v", sync, err)
+	}
+	if !sync.SyncedFrom.Equal(cutoff) {
+		t.Errorf("Expected sync to start at %v, got %v", cutoff, sync.SyncedFrom)
+	}
+}
+
+func TestInvalidatePRForcesRefetch(t *testing.T) {
+	db := seedCacheDatabase(t)
+
+	deletion, err := db.InvalidatePR("", "org", "api", 2)
+	if err != nil {
+		t.Fatalf("InvalidatePR() error = %v", err)
+	}
+	if *deletion != (CacheDeletion{PRs: 1, Comments: 1, Reactions: 1}) {
+		t.Errorf("Expected one PR invalidated and one comment and reaction removed, got %+v", deletion)
+	}
+
+	pr, err := db.GetPR("org", "api", 2)
+	if err != nil || pr == nil {
+		t.Fatalf("Expected PR to stay cached, got %v (%v)", pr, err)
+	}
+	if pr.IssueCommentsChecked || pr.HasIssueComments {
+		t.Errorf("Expected comment flags to be reset, got %+v", pr)
+	}
+	if comments, _ := db.GetCommentsByPR("org", "api", 2); len(comments) != 0 {
+		t.Errorf("Expected comments to be removed, got %d", len(comments))
+	}
+
+	if err := db.Vacuum(); err != nil {
+		t.Errorf("Vacuum() error = %v", err)
+	}
+}
+
+func TestHostsAreCachedSeparately(t *testing.T) {
+	db, err := NewSQLiteDatabase(filepath.Join(t.TempDir(), "hosts.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	ghe := db.WithHost("ghe.corp.com")
+
+	// O mesmo owner/repo, PR e ID de comentário em duas instâncias
+	for host, view := range map[string]CommentDatabase{DefaultHost: db, "ghe.corp.com": ghe} {
+		if err := view.SavePR(&PRData{RepoOwner: "org", RepoName: "api", PRNumber: 1, Title: host, Username: "alice", MergedAt: now, CachedAt: now}); err != nil {
+			t.Fatalf("SavePR() error = %v", err)
+		}
+		if err := view.SaveComment(&CommentData{RepoOwner: "org", RepoName: "api", PRNumber: 1, CommentID: 100, CommentType: "issue", Username: "bob", Body: host, CreatedAt: now, UpdatedAt: now, CachedAt: now}); err != nil {
+			t.Fatalf("SaveComment() error = %v", err)
+		}
+	}
+	if err := ghe.SaveReactions(100, "issue_comment", []*ReactionData{{CommentID: 100, ReactionType: "issue_comment", Content: "+1", Username: "carol", CachedAt: now}}); err != nil {
+		t.Fatalf("SaveReactions() error = %v", err)
+	}
+	if err := ghe.SaveRepoSync(&RepoSyncData{RepoOwner: "org", RepoName: "api", SyncedFrom: now, SyncedUntil: now, LastSyncedAt: now}); err != nil {
+		t.Fatalf("SaveRepoSync() error = %v", err)
+	}
+
+	if pr, _ := ghe.GetPR("org", "api", 1); pr == nil || pr.Title != "ghe.corp.com" {
+		t.Errorf("Expected the ghe.corp.com PR, got %+v", pr)
+	}
+	if comment, _ := db.GetComment("org", "api", 100); comment == nil || comment.Body != DefaultHost {
+		t.Errorf("Expected the github.com comment, got %+v", comment)
+	}
+	if reactions, _ := db.GetReactions(100); len(reactions) != 0 {
+		t.Errorf("Expected no reactions on github.com, got %d", len(reactions))
+	}
+	if sync, _ := db.GetRepoSync("org", "api"); sync != nil {
+		t.Errorf("Expected org/api never synced on github.com, got %+v", sync)
+	}
+
+	stats, err := db.Stats(olderThan(24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if len(stats.Repos) != 2 || stats.Repos[0].Host != "ghe.corp.com" || stats.Repos[0].Reactions != 1 || stats.Repos[1].Host != DefaultHost {
+		t.Errorf("Expected separate stats per host, got %+v", stats.Repos)
+	}
+
+	// Invalidar com host afeta apenas a instância informada
+	deletion, err := db.InvalidatePR("ghe.corp.com", "org", "api", 1)
+	if err != nil {
+		t.Fatalf("InvalidatePR() error = %v", err)
+	}
+	if *deletion != (CacheDeletion{PRs: 1, Comments: 1, Reactions: 1}) {
+		t.Errorf("Expected only the ghe.corp.com PR invalidated, got %+v", deletion)
+	}
+	if comment, _ := db.GetComment("org", "api", 100); comment == nil {
+		t.Error("Expected the github.com comment to stay cached")
+	}
+}
+
+func TestGetCommentIsScopedToRepository(t *testing.T) {
+	db, err := NewSQLiteDatabase(filepath.Join(t.TempDir(), "comments.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	if err := db.SaveComment(&CommentData{RepoOwner: "org", RepoName: "api", PRNumber: 1, CommentID: 100, CommentType: "issue", Username: "bob", Body: "lgtm", CreatedAt: now, UpdatedAt: now, CachedAt: now}); err != nil {
+		t.Fatalf("SaveComment() error = %v", err)
+	}
+
+	if comment, err := db.GetComment("org", "api", 100); err != nil || comment == nil {
+		t.Fatalf("Expected the comment in org/api, got %+v (%v)", comment, err)
+	}
+	if comment, err := db.GetComment("org", "web", 100); err != nil || comment != nil {
+		t.Errorf("Expected no comment 100 in org/web, got %+v (%v)", comment, err)
+	}
+}
@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/go-github/v70/github"
@@ -13,12 +14,19 @@ import (
 type CachedGithubAdapter struct {
 	githubClient GithubAdapter
 	db           database.CommentDatabase
+	freshness    *FreshnessPolicy // Política de validade do cache (nil = padrão)
+	hosts        *RepositoryHosts // Host de cada repositório no cache (nil = github.com)
+	usage        usageCounters    // Hits e misses do cache desta execução
+	progressOut  io.Writer        // Saída das mensagens de progresso (nil = saída padrão)
 }
 
 // NewCachedGithubAdapter cria um novo adaptador com cache em banco de dados
 func NewCachedGithubAdapter(token string, dbPath string) (CacheableGithubAdapter, error) {
-	githubClient := NewGithubClient(token)
+	return NewCachedGithubAdapterWithClient(NewGithubClient(token), dbPath)
+}
 
+// NewCachedGithubAdapterWithClient cria um adaptador com cache sobre o cliente informado (REST ou GraphQL)
+func NewCachedGithubAdapterWithClient(githubClient GithubAdapter, dbPath string) (CacheableGithubAdapter, error) {
 	db, err := database.NewSQLiteDatabase(dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao inicializar banco de dados: %v", err)
@@ -30,10 +38,46 @@ func NewCachedGithubAdapter(token string, dbPath string) (CacheableGithubAdapter
 	}, nil
 }
 
+// SetFreshnessPolicy define quando comentários e reações em cache são buscados novamente
+func (c *CachedGithubAdapter) SetFreshnessPolicy(policy FreshnessPolicy) {
+	c.freshness = &policy
+}
+
+// freshnessPolicy retorna a política de validade em uso (ou a padrão)
+func (c *CachedGithubAdapter) freshnessPolicy() FreshnessPolicy {
+	if c.freshness == nil {
+		return DefaultFreshnessPolicy()
+	}
+	return *c.freshness
+}
+
+// SetRepositoryHosts define o host de cada repositório, usado para separar os dados no cache
+func (c *CachedGithubAdapter) SetRepositoryHosts(hosts *RepositoryHosts) {
+	c.hosts = hosts
+}
+
+// repoDB retorna o banco restrito ao host do repositório
+func (c *CachedGithubAdapter) repoDB(owner, repo string) database.CommentDatabase {
+	return c.db.WithHost(c.hosts.Host(owner, repo))
+}
+
+// SetProgressOutput define onde as mensagens de progresso são escritas, repassando-a ao cliente da API
+func (c *CachedGithubAdapter) SetProgressOutput(w io.Writer) {
+	c.progressOut = w
+	setProgressOutput(c.githubClient, w)
+}
+
+// progress retorna a saída das mensagens de progresso
+func (c *CachedGithubAdapter) progress() io.Writer {
+	return progressOutput(c.progressOut)
+}
+
 // ensurePRExists garante que o PR existe no cache com dados completos
 func (c *CachedGithubAdapter) ensurePRExists(ctx context.Context, owner, repo string, prNumber int) error {
+	db := c.repoDB(owner, repo)
+
 	// Verifica se o PR já existe no cache
-	existingPR, err := c.db.GetPR(owner, repo, prNumber)
+	existingPR, err := db.GetPR(owner, repo, prNumber)
 	if err != nil {
 		return fmt.Errorf("erro ao verificar PR existente: %v", err)
 	}
@@ -51,17 +95,112 @@ func (c *CachedGithubAdapter) ensurePRExists(ctx context.Context, owner, repo st
 
 	// Converte e salva o PR completo
 	prData := database.FromGithubPR(pr, owner, repo)
-	if err := c.db.SavePR(prData); err != nil {
+	if err := db.SavePR(prData); err != nil {
 		return fmt.Errorf("erro ao salvar PR completo: %v", err)
 	}
 
 	return nil
 }
 
-// FetchPRsForRepo implementa a interface GithubAdapter (sem cache para PRs)
+// FetchPRsForRepo busca os PRs mergeados de um repositório com cache e sincronização incremental.
+// Períodos já sincronizados são respondidos pelo banco; quando o período avança além da última
+// sincronização, apenas os PRs mergeados ou atualizados desde então são buscados da API.
 func (c *CachedGithubAdapter) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
-	// Para PRs, não aplicamos cache pois são menos frequentes e mudam menos
-	return c.githubClient.FetchPRsForRepo(owner, name, startDate, endDate)
+	// Mesmo intervalo usado pelo githubAdapter: mergeados após startDate e até o fim do dia endDate
+	until := endDate.Add(24 * time.Hour)
+	db := c.repoDB(owner, name)
+
+	sync, err := db.GetRepoSync(owner, name)
+	if err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar sincronização de %s/%s: %v\n", owner, name, err)
+		sync = nil
+	}
+
+	if sync.Covers(startDate, until) {
+		fmt.Fprintf(c.progress(), "    📋 Cache HIT: PRs de %s/%s já sincronizados até %s\n", owner, name, sync.SyncedUntil.Format("2006-01-02 15:04"))
+		c.usage.hit(ResourcePRs)
+		return mergedPRsFromDatabase(db, owner, name, startDate, until)
+	}
+
+	// Se o início do período já está sincronizado, basta buscar o que mudou desde a última sincronização
+	incremental := sync != nil && !startDate.Before(sync.SyncedFrom) && !startDate.After(sync.SyncedUntil)
+	fetchFrom := startDate
+	if incremental {
+		fetchFrom = sync.SyncedUntil
+		fmt.Fprintf(c.progress(), "    🌐 Cache MISS: Buscando PRs de %s/%s atualizados desde %s\n", owner, name, fetchFrom.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Fprintf(c.progress(), "    🌐 Cache MISS: Buscando PRs de %s/%s da API\n", owner, name)
+	}
+
+	c.usage.miss(ResourcePRs)
+	fetchedAt := time.Now()
+	prs, err := c.githubClient.FetchPRsForRepo(owner, name, fetchFrom, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var prData []*database.PRData
+	for _, pr := range prs {
+		prData = append(prData, database.FromGithubPR(pr, owner, name))
+	}
+	if err := db.SaveMergedPRs(prData); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar PRs no cache: %v\n", err)
+		if incremental {
+			return nil, fmt.Errorf("erro ao salvar PRs no cache: %v", err)
+		}
+		return prs, nil // Sem atualizar a marca d'água, a próxima execução busca tudo de novo
+	}
+
+	// PRs ainda podem ser mergeados depois desta busca: a marca d'água nunca passa do momento da busca
+	newSync := &database.RepoSyncData{
+		RepoOwner:    owner,
+		RepoName:     name,
+		SyncedFrom:   fetchFrom,
+		SyncedUntil:  until,
+		LastSyncedAt: fetchedAt,
+	}
+	if fetchedAt.Before(until) {
+		newSync.SyncedUntil = fetchedAt
+	}
+	mergeRepoSync(newSync, sync)
+
+	if err := db.SaveRepoSync(newSync); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar sincronização de %s/%s: %v\n", owner, name, err)
+	}
+
+	if incremental {
+		// Junta os PRs já sincronizados com os recém-buscados
+		return mergedPRsFromDatabase(db, owner, name, startDate, until)
+	}
+	return prs, nil
+}
+
+// mergeRepoSync estende o intervalo sincronizado com o anterior quando os dois se tocam;
+// intervalos disjuntos não podem ser unidos e o anterior é descartado
+func mergeRepoSync(current, previous *database.RepoSyncData) {
+	if previous == nil || previous.SyncedUntil.Before(current.SyncedFrom) || current.SyncedUntil.Before(previous.SyncedFrom) {
+		return
+	}
+	if previous.SyncedFrom.Before(current.SyncedFrom) {
+		current.SyncedFrom = previous.SyncedFrom
+	}
+	if previous.SyncedUntil.After(current.SyncedUntil) {
+		current.SyncedUntil = previous.SyncedUntil
+	}
+}
+
+// mergedPRsFromDatabase responde a busca de PRs mergeados inteiramente pelo banco
+func mergedPRsFromDatabase(db database.CommentDatabase, owner, name string, startDate, until time.Time) ([]*github.PullRequest, error) {
+	cached, err := db.GetMergedPRs(owner, name, startDate, until)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar PRs do cache: %v", err)
+	}
+
+	var prs []*github.PullRequest
+	for _, pr := range cached {
+		prs = append(prs, pr.ToGithubPR())
+	}
+	return prs, nil
 }
 
 // GetPR implementa a interface GithubAdapter
@@ -71,55 +210,65 @@ func (c *CachedGithubAdapter) GetPR(ctx context.Context, owner, repo string, prN
 
 // ListPRComments busca comentários de um PR com cache
 func (c *CachedGithubAdapter) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	db := c.repoDB(owner, repo)
+
 	// Primeiro verifica se já temos informações sobre este PR
-	prData, err := c.db.GetPR(owner, repo, prNumber)
-	if err == nil && prData != nil {
+	prData, err := db.GetPR(owner, repo, prNumber)
+	if err != nil {
+		prData = nil
+	}
+	mergedAt := prMergedAt(prData)
+	if prData != nil && !c.freshnessPolicy().alwaysRefresh(mergedAt, time.Now()) {
 		// Se já verificamos que este PR não tem issue comments, retorna lista vazia
 		if prData.IssueCommentsChecked && !prData.HasIssueComments {
-			fmt.Printf("    📋 Cache HIT: PR #%d em %s/%s confirmado sem issue comments\n", prNumber, owner, repo)
+			fmt.Fprintf(c.progress(), "    📋 Cache HIT: PR #%d em %s/%s confirmado sem issue comments\n", prNumber, owner, repo)
+			c.usage.hit(ResourceIssueComments)
 			return []*github.IssueComment{}, nil
 		}
 	}
 
 	// Busca comentários existentes no cache
-	cachedComments, err := c.db.GetCommentsByPRAndType(owner, repo, prNumber, "issue")
+	cachedComments, err := db.GetCommentsByPRAndType(owner, repo, prNumber, "issue")
 	if err != nil {
-		fmt.Printf("    ⚠️  Erro ao buscar comentários do cache: %v\n", err)
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar comentários do cache: %v\n", err)
 		// Continua para buscar da API em caso de erro
 	}
 
 	// Verifica se temos comentários válidos no cache
-	if len(cachedComments) > 0 && !c.areCommentsStale(cachedComments) {
-		// fmt.Printf("    📋 Cache HIT: Comentários do PR #%d em %s/%s\n", prNumber, owner, repo)
-		return c.convertCachedCommentsToGithub(cachedComments), nil
+	if len(cachedComments) > 0 && !c.areCommentsStale(cachedComments, mergedAt) {
+		// fmt.Fprintf(c.progress(), "    📋 Cache HIT: Comentários do PR #%d em %s/%s\n", prNumber, owner, repo)
+		c.usage.hit(ResourceIssueComments)
+		return convertCachedCommentsToGithub(cachedComments), nil
 	}
 
 	// Cache MISS - busca da API
-	fmt.Printf("    🌐 Cache MISS: Buscando comentários do PR #%d em %s/%s da API\n", prNumber, owner, repo)
+	fmt.Fprintf(c.progress(), "    🌐 Cache MISS: Buscando comentários do PR #%d em %s/%s da API\n", prNumber, owner, repo)
 
 	// Garante que temos dados completos do PR antes de buscar comentários
 	if err := c.ensurePRExists(ctx, owner, repo, prNumber); err != nil {
-		fmt.Printf("    ⚠️  Erro ao garantir dados do PR: %v\n", err)
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao garantir dados do PR: %v\n", err)
 		// Continua mesmo com erro, pois os comentários ainda podem ser buscados
 	}
 
+	c.usage.miss(ResourceIssueComments)
 	comments, err := c.githubClient.ListPRComments(ctx, owner, repo, prNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	// Salva os comentários no cache
+	// Substitui os comentários em cache: os removidos no GitHub deixam o cache junto com suas reações
+	var commentData []*database.CommentData
 	for _, comment := range comments {
-		commentData := database.FromGithubIssueComment(comment, owner, repo, prNumber)
-		if err := c.db.SaveComment(commentData); err != nil {
-			fmt.Printf("    ⚠️  Erro ao salvar comentário no cache: %v\n", err)
-		}
+		commentData = append(commentData, database.FromGithubIssueComment(comment, owner, repo, prNumber))
+	}
+	if err := db.SaveComments(owner, repo, prNumber, "issue", commentData); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar comentários no cache: %v\n", err)
 	}
 
 	// Marca o PR como verificado para issue comments
 	hasComments := len(comments) > 0
-	if err := c.db.MarkPRCommentsChecked(owner, repo, prNumber, "issue", hasComments); err != nil {
-		fmt.Printf("    ⚠️  Erro ao marcar PR como verificado: %v\n", err)
+	if err := db.MarkPRCommentsChecked(owner, repo, prNumber, "issue", hasComments); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao marcar PR como verificado: %v\n", err)
 	}
 
 	return comments, nil
@@ -127,54 +276,64 @@ func (c *CachedGithubAdapter) ListPRComments(ctx context.Context, owner, repo st
 
 // ListPRReviewComments busca review comments de um PR com cache
 func (c *CachedGithubAdapter) ListPRReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.PullRequestComment, error) {
+	db := c.repoDB(owner, repo)
+
 	// Primeiro verifica se já temos informações sobre este PR
-	prData, err := c.db.GetPR(owner, repo, prNumber)
-	if err == nil && prData != nil {
+	prData, err := db.GetPR(owner, repo, prNumber)
+	if err != nil {
+		prData = nil
+	}
+	mergedAt := prMergedAt(prData)
+	if prData != nil && !c.freshnessPolicy().alwaysRefresh(mergedAt, time.Now()) {
 		// Se já verificamos que este PR não tem review comments, retorna lista vazia
 		if prData.ReviewCommentsChecked && !prData.HasReviewComments {
-			fmt.Printf("    📋 Cache HIT: PR #%d em %s/%s confirmado sem review comments\n", prNumber, owner, repo)
+			fmt.Fprintf(c.progress(), "    📋 Cache HIT: PR #%d em %s/%s confirmado sem review comments\n", prNumber, owner, repo)
+			c.usage.hit(ResourceReviewComments)
 			return []*github.PullRequestComment{}, nil
 		}
 	}
 
 	// Busca review comments existentes no cache
-	cachedComments, err := c.db.GetCommentsByPRAndType(owner, repo, prNumber, "review")
+	cachedComments, err := db.GetCommentsByPRAndType(owner, repo, prNumber, "review")
 	if err != nil {
-		fmt.Printf("    ⚠️  Erro ao buscar review comments do cache: %v\n", err)
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar review comments do cache: %v\n", err)
 	}
 
 	// Verifica se temos review comments válidos no cache
-	if len(cachedComments) > 0 && !c.areCommentsStale(cachedComments) {
-		// fmt.Printf("    📋 Cache HIT: Review comments do PR #%d em %s/%s\n", prNumber, owner, repo)
-		return c.convertCachedReviewCommentsToGithub(cachedComments), nil
+	if len(cachedComments) > 0 && !c.areCommentsStale(cachedComments, mergedAt) {
+		// fmt.Fprintf(c.progress(), "    📋 Cache HIT: Review comments do PR #%d em %s/%s\n", prNumber, owner, repo)
+		c.usage.hit(ResourceReviewComments)
+		return convertCachedReviewCommentsToGithub(cachedComments), nil
 	}
 
 	// Cache MISS - busca da API
-	fmt.Printf("    🌐 Cache MISS: Buscando review comments do PR #%d em %s/%s da API\n", prNumber, owner, repo)
+	fmt.Fprintf(c.progress(), "    🌐 Cache MISS: Buscando review comments do PR #%d em %s/%s da API\n", prNumber, owner, repo)
 
 	// Garante que temos dados completos do PR antes de buscar review comments
 	if err := c.ensurePRExists(ctx, owner, repo, prNumber); err != nil {
-		fmt.Printf("    ⚠️  Erro ao garantir dados do PR: %v\n", err)
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao garantir dados do PR: %v\n", err)
 		// Continua mesmo com erro, pois os review comments ainda podem ser buscados
 	}
 
+	c.usage.miss(ResourceReviewComments)
 	reviewComments, err := c.githubClient.ListPRReviewComments(ctx, owner, repo, prNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	// Salva os review comments no cache
+	// Substitui os review comments em cache: os removidos no GitHub deixam o cache junto com suas reações
+	var commentData []*database.CommentData
 	for _, comment := range reviewComments {
-		commentData := database.FromGithubReviewComment(comment, owner, repo, prNumber)
-		if err := c.db.SaveComment(commentData); err != nil {
-			fmt.Printf("    ⚠️  Erro ao salvar review comment no cache: %v\n", err)
-		}
+		commentData = append(commentData, database.FromGithubReviewComment(comment, owner, repo, prNumber))
+	}
+	if err := db.SaveComments(owner, repo, prNumber, "review", commentData); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar review comments no cache: %v\n", err)
 	}
 
 	// Marca o PR como verificado para review comments
 	hasComments := len(reviewComments) > 0
-	if err := c.db.MarkPRCommentsChecked(owner, repo, prNumber, "review", hasComments); err != nil {
-		fmt.Printf("    ⚠️  Erro ao marcar PR como verificado: %v\n", err)
+	if err := db.MarkPRCommentsChecked(owner, repo, prNumber, "review", hasComments); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao marcar PR como verificado: %v\n", err)
 	}
 
 	return reviewComments, nil
@@ -182,27 +341,31 @@ func (c *CachedGithubAdapter) ListPRReviewComments(ctx context.Context, owner, r
 
 // ListIssueCommentReactions busca reações de um comentário com cache
 func (c *CachedGithubAdapter) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	db := c.repoDB(owner, repo)
+
 	// Primeiro, verifica se o comentário existe no cache e se suas reações já foram verificadas
-	comment, err := c.db.GetComment(owner, repo, commentID)
+	comment, err := db.GetComment(owner, repo, commentID)
 	if err != nil {
-		fmt.Printf("    ⚠️  Erro ao buscar comentário do cache: %v\n", err)
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar comentário do cache: %v\n", err)
 	}
 
 	// Se o comentário existe e as reações já foram verificadas, e não está stale
-	if comment != nil && comment.ReactionsChecked && !c.isCommentStale(comment) {
+	if comment != nil && comment.ReactionsChecked && !c.isCommentStale(comment, c.commentMergedAt(owner, repo, comment)) {
 		// Busca as reações do cache (especificamente issue_comment type)
-		cachedReactions, err := c.db.GetReactionsByType(commentID, "issue_comment")
+		cachedReactions, err := db.GetReactionsByType(commentID, "issue_comment")
 		if err != nil {
-			fmt.Printf("    ⚠️  Erro ao buscar reações do cache: %v\n", err)
+			fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar reações do cache: %v\n", err)
 		} else {
-			// fmt.Printf("    📋 Cache HIT: Reações do comentário %d (%d reações)\n", commentID, len(cachedReactions))
-			return c.convertCachedReactionsToGithub(cachedReactions), nil
+			// fmt.Fprintf(c.progress(), "    📋 Cache HIT: Reações do comentário %d (%d reações)\n", commentID, len(cachedReactions))
+			c.usage.hit(ResourceReactions)
+			return convertCachedReactionsToGithub(cachedReactions), nil
 		}
 	}
 
 	// Cache MISS ou dados stale - busca da API
-	fmt.Printf("    🌐 Cache MISS: Buscando reações do comentário %d da API\n", commentID)
+	fmt.Fprintf(c.progress(), "    🌐 Cache MISS: Buscando reações do comentário %d da API\n", commentID)
 
+	c.usage.miss(ResourceReactions)
 	reactions, err := c.githubClient.ListIssueCommentReactions(ctx, owner, repo, commentID)
 	if err != nil {
 		return nil, err
@@ -214,42 +377,46 @@ func (c *CachedGithubAdapter) ListIssueCommentReactions(ctx context.Context, own
 		reactionData = append(reactionData, database.FromGithubReaction(reaction, commentID))
 	}
 
-	if err := c.db.SaveReactions(reactionData); err != nil {
-		fmt.Printf("    ⚠️  Erro ao salvar reações no cache: %v\n", err)
+	if err := db.SaveReactions(commentID, "issue_comment", reactionData); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar reações no cache: %v\n", err)
 	}
 
 	// Marca que as reações deste comentário foram verificadas
-	if err := c.db.MarkReactionsChecked(commentID); err != nil {
-		fmt.Printf("    ⚠️  Erro ao marcar reações como verificadas: %v\n", err)
+	if err := db.MarkReactionsChecked(commentID); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao marcar reações como verificadas: %v\n", err)
 	}
 
-	fmt.Printf("    ✅ Reações do comentário %d salvas (%d reações encontradas)\n", commentID, len(reactions))
+	fmt.Fprintf(c.progress(), "    ✅ Reações do comentário %d salvas (%d reações encontradas)\n", commentID, len(reactions))
 	return reactions, nil
 }
 
 // ListPullRequestCommentReactions busca reações de um review comment com cache
 func (c *CachedGithubAdapter) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	db := c.repoDB(owner, repo)
+
 	// Primeiro, verifica se o comentário existe no cache e se suas reações já foram verificadas
-	comment, err := c.db.GetComment(owner, repo, commentID)
+	comment, err := db.GetComment(owner, repo, commentID)
 	if err != nil {
-		fmt.Printf("    ⚠️  Erro ao buscar review comment do cache: %v\n", err)
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar review comment do cache: %v\n", err)
 	}
 
 	// Se o comentário existe e as reações já foram verificadas, e não está stale
-	if comment != nil && comment.ReactionsChecked && !c.isCommentStale(comment) {
+	if comment != nil && comment.ReactionsChecked && !c.isCommentStale(comment, c.commentMergedAt(owner, repo, comment)) {
 		// Busca as reações do cache (especificamente review_comment type)
-		cachedReactions, err := c.db.GetReactionsByType(commentID, "review_comment")
+		cachedReactions, err := db.GetReactionsByType(commentID, "review_comment")
 		if err != nil {
-			fmt.Printf("    ⚠️  Erro ao buscar reações de review comment do cache: %v\n", err)
+			fmt.Fprintf(c.progress(), "    ⚠️  Erro ao buscar reações de review comment do cache: %v\n", err)
 		} else {
-			// fmt.Printf("    📋 Cache HIT: Reações do review comment %d (%d reações)\n", commentID, len(cachedReactions))
-			return c.convertCachedReactionsToGithub(cachedReactions), nil
+			// fmt.Fprintf(c.progress(), "    📋 Cache HIT: Reações do review comment %d (%d reações)\n", commentID, len(cachedReactions))
+			c.usage.hit(ResourceReactions)
+			return convertCachedReactionsToGithub(cachedReactions), nil
 		}
 	}
 
 	// Cache MISS ou dados stale - busca da API
-	fmt.Printf("    🌐 Cache MISS: Buscando reações do review comment %d da API\n", commentID)
+	fmt.Fprintf(c.progress(), "    🌐 Cache MISS: Buscando reações do review comment %d da API\n", commentID)
 
+	c.usage.miss(ResourceReactions)
 	reactions, err := c.githubClient.ListPullRequestCommentReactions(ctx, owner, repo, commentID)
 	if err != nil {
 		return nil, err
@@ -261,22 +428,42 @@ func (c *CachedGithubAdapter) ListPullRequestCommentReactions(ctx context.Contex
 		reactionData = append(reactionData, database.FromGithubReviewReaction(reaction, commentID))
 	}
 
-	if err := c.db.SaveReactions(reactionData); err != nil {
-		fmt.Printf("    ⚠️  Erro ao salvar reações de review comment no cache: %v\n", err)
+	if err := db.SaveReactions(commentID, "review_comment", reactionData); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar reações de review comment no cache: %v\n", err)
 	}
 
 	// Marca que as reações deste comentário foram verificadas
-	if err := c.db.MarkReactionsChecked(commentID); err != nil {
-		fmt.Printf("    ⚠️  Erro ao marcar reações de review comment como verificadas: %v\n", err)
+	if err := db.MarkReactionsChecked(commentID); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao marcar reações de review comment como verificadas: %v\n", err)
 	}
 
-	fmt.Printf("    ✅ Reações do review comment %d salvas (%d reações encontradas)\n", commentID, len(reactions))
+	fmt.Fprintf(c.progress(), "    ✅ Reações do review comment %d salvas (%d reações encontradas)\n", commentID, len(reactions))
 	return reactions, nil
 }
 
+// RateLimit retorna o último estado conhecido do rate limit da API (quando o cliente o acompanha)
+func (c *CachedGithubAdapter) RateLimit() RateLimitStatus {
+	if reporter, ok := c.githubClient.(RateLimitReporter); ok {
+		return reporter.RateLimit()
+	}
+	return RateLimitStatus{}
+}
+
+// Usage retorna os hits e misses do cache, as requisições à API e o rate limit desta execução
+func (c *CachedGithubAdapter) Usage() UsageStats {
+	stats := c.usage.snapshot()
+	if reporter, ok := c.githubClient.(APIUsageReporter); ok {
+		api := reporter.APIUsage()
+		stats.APICalls = api.Requests
+		stats.APITime = api.Time
+	}
+	stats.RateLimit = c.RateLimit()
+	return stats
+}
+
 // ClearCache limpa todo o cache do banco de dados
 func (c *CachedGithubAdapter) ClearCache() error {
-	fmt.Println("🗑️  Limpando cache do banco de dados...")
+	fmt.Fprintln(c.progress(), "🗑️  Limpando cache do banco de dados...")
 	return c.db.ClearDatabase()
 }
 
@@ -288,26 +475,44 @@ func (c *CachedGithubAdapter) Close() error {
 	return nil
 }
 
-// isCommentStale verifica se um comentário específico está desatualizado
-func (c *CachedGithubAdapter) isCommentStale(comment *database.CommentData) bool {
-	cacheDuration := 7 * 24 * time.Hour // 7 dias
-	return time.Since(comment.CachedAt) > cacheDuration
+// isCommentStale verifica, pela política de validade, se um comentário e suas reações estão desatualizados
+func (c *CachedGithubAdapter) isCommentStale(comment *database.CommentData, mergedAt time.Time) bool {
+	return c.freshnessPolicy().IsStale(comment.CachedAt, mergedAt, time.Now())
 }
 
-// areCommentsStale verifica se os comentários em cache estão desatualizados
-func (c *CachedGithubAdapter) areCommentsStale(comments []*database.CommentData) bool {
-	cacheDuration := 7 * 24 * time.Hour // 7 dias
+// areCommentsStale verifica se algum dos comentários em cache de um PR está desatualizado
+func (c *CachedGithubAdapter) areCommentsStale(comments []*database.CommentData, mergedAt time.Time) bool {
+	policy := c.freshnessPolicy()
+	now := time.Now()
 
 	for _, comment := range comments {
-		if time.Since(comment.CachedAt) > cacheDuration {
+		if policy.IsStale(comment.CachedAt, mergedAt, now) {
 			return true
 		}
 	}
 	return false
 }
 
+// commentMergedAt retorna a data de merge do PR do comentário (zero quando o PR não está no cache)
+func (c *CachedGithubAdapter) commentMergedAt(owner, repo string, comment *database.CommentData) time.Time {
+	prData, err := c.repoDB(owner, repo).GetPR(owner, repo, comment.PRNumber)
+	if err != nil {
+		return time.Time{}
+	}
+	return prMergedAt(prData)
+}
+
+// prMergedAt retorna a data de merge de um PR em cache; registros básicos (sem título),
+// criados antes dos dados completos do PR, não têm data de merge confiável
+func prMergedAt(prData *database.PRData) time.Time {
+	if prData == nil || prData.Title == "" {
+		return time.Time{}
+	}
+	return prData.MergedAt
+}
+
 // convertCachedCommentsToGithub converte comentários do cache para formato GitHub
-func (c *CachedGithubAdapter) convertCachedCommentsToGithub(cachedComments []*database.CommentData) []*github.IssueComment {
+func convertCachedCommentsToGithub(cachedComments []*database.CommentData) []*github.IssueComment {
 	var comments []*github.IssueComment
 
 	for _, cached := range cachedComments {
@@ -329,7 +534,7 @@ func (c *CachedGithubAdapter) convertCachedCommentsToGithub(cachedComments []*da
 }
 
 // convertCachedReviewCommentsToGithub converte review comments do cache para formato GitHub
-func (c *CachedGithubAdapter) convertCachedReviewCommentsToGithub(cachedComments []*database.CommentData) []*github.PullRequestComment {
+func convertCachedReviewCommentsToGithub(cachedComments []*database.CommentData) []*github.PullRequestComment {
 	var comments []*github.PullRequestComment
 
 	for _, cached := range cachedComments {
@@ -351,15 +556,17 @@ func (c *CachedGithubAdapter) convertCachedReviewCommentsToGithub(cachedComments
 }
 
 // convertCachedReactionsToGithub converte reações do cache para formato GitHub
-func (c *CachedGithubAdapter) convertCachedReactionsToGithub(cachedReactions []*database.ReactionData) []*github.Reaction {
+func convertCachedReactionsToGithub(cachedReactions []*database.ReactionData) []*github.Reaction {
 	var reactions []*github.Reaction
 
 	for _, cached := range cachedReactions {
 		reaction := &github.Reaction{
+			ID:      &cached.ReactionID,
 			Content: &cached.Content,
 			User: &github.User{
 				Login: &cached.Username,
 			},
+			CreatedAt: &github.Timestamp{Time: cached.CreatedAt},
 		}
 		reactions = append(reactions, reaction)
 	}
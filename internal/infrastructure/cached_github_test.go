@@ -0,0 +1,287 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+// fakePRsClient é um GithubAdapter em memória que registra os intervalos buscados
+type fakePRsClient struct {
+	prs   []*github.PullRequest
+	calls [][2]time.Time
+}
+
+func (f *fakePRsClient) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
+	f.calls = append(f.calls, [2]time.Time{startDate, endDate})
+
+	var result []*github.PullRequest
+	for _, pr := range f.prs {
+		mergedAt := pr.GetMergedAt().Time
+		if mergedAt.After(startDate) && mergedAt.Before(endDate.Add(24*time.Hour)) {
+			result = append(result, pr)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakePRsClient) GetPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	return nil, fmt.Errorf("não implementado")
+}
+
+func (f *fakePRsClient) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return nil, nil
+}
+
+func (f *fakePRsClient) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return nil, nil
+}
+
+func (f *fakePRsClient) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	return nil, nil
+}
+
+func (f *fakePRsClient) ListPRReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.PullRequestComment, error) {
+	return nil, nil
+}
+
+// newTestCachedAdapter cria um CachedGithubAdapter com banco SQLite temporário
+func newTestCachedAdapter(t *testing.T, client GithubAdapter) *CachedGithubAdapter {
+	t.Helper()
+
+	db, err := database.NewSQLiteDatabase(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDatabase() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &CachedGithubAdapter{githubClient: client, db: db}
+}
+
+func mergedPR(number int, mergedAt time.Time) *github.PullRequest {
+	return &github.PullRequest{
+		Number:    github.Int(number),
+		Title:     github.String(fmt.Sprintf("PR %d", number)),
+		User:      &github.User{Login: github.String("alice")},
+		MergedAt:  &github.Timestamp{Time: mergedAt},
+		UpdatedAt: &github.Timestamp{Time: mergedAt},
+		Base:      &github.PullRequestBranch{Ref: github.String("main")},
+	}
+}
+
+func prNumbers(prs []*github.PullRequest) map[int]bool {
+	numbers := make(map[int]bool)
+	for _, pr := range prs {
+		numbers[pr.GetNumber()] = true
+	}
+	return numbers
+}
+
+func TestCachedFetchPRsForRepoAnswersHistoricalRangeFromDatabase(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	client := &fakePRsClient{prs: []*github.PullRequest{mergedPR(1, day(3)), mergedPR(2, day(10)), mergedPR(3, day(20))}}
+	adapter := newTestCachedAdapter(t, client)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	first, err := adapter.FetchPRsForRepo("org", "api", start, end)
+	if err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if len(first) != 3 {
+		t.Fatalf("Expected 3 PRs from the API, got %d", len(first))
+	}
+
+	// Um subintervalo já sincronizado é respondido sem chamar a API
+	subStart := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	subEnd := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	cached, err := adapter.FetchPRsForRepo("org", "api", subStart, subEnd)
+	if err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if len(client.calls) != 1 {
+		t.Errorf("Expected historical range to be served from the database, API called %d times", len(client.calls))
+	}
+	if numbers := prNumbers(cached); len(numbers) != 1 || !numbers[2] {
+		t.Errorf("Expected only PR #2 from the cache, got %v", numbers)
+	}
+
+	pr := cached[0]
+	if pr.GetUser().GetLogin() != "alice" || pr.GetBase().GetRef() != "main" || pr.GetBase().GetRepo().GetName() != "api" {
+		t.Errorf("Cached PR lost fields: %+v", pr)
+	}
+	if !pr.GetMergedAt().Time.Equal(day(10)) {
+		t.Errorf("Expected merged_at %v, got %v", day(10), pr.GetMergedAt().Time)
+	}
+}
+
+func TestCachedFetchPRsForRepoFetchesOnlySinceLastSync(t *testing.T) {
+	now := time.Now().UTC()
+	client := &fakePRsClient{prs: []*github.PullRequest{mergedPR(1, now.Add(-20*24*time.Hour)), mergedPR(2, now.Add(-5*24*time.Hour))}}
+	adapter := newTestCachedAdapter(t, client)
+
+	start := now.Add(-30 * 24 * time.Hour)
+	firstEnd := now.Add(-10 * 24 * time.Hour)
+	if _, err := adapter.FetchPRsForRepo("org", "api", start, firstEnd); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	// O período avança até hoje: apenas o intervalo após a última sincronização vai para a API
+	prs, err := adapter.FetchPRsForRepo("org", "api", start, now)
+	if err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if len(client.calls) != 2 {
+		t.Fatalf("Expected 2 API calls, got %d", len(client.calls))
+	}
+	if incrementalStart := client.calls[1][0]; !incrementalStart.Equal(firstEnd.Add(24 * time.Hour)) {
+		t.Errorf("Expected incremental fetch from %v, got %v", firstEnd.Add(24*time.Hour), incrementalStart)
+	}
+	if numbers := prNumbers(prs); len(numbers) != 2 || !numbers[1] || !numbers[2] {
+		t.Errorf("Expected PRs #1 and #2 merged from cache and API, got %v", numbers)
+	}
+
+	// O período atual ainda pode receber merges: a próxima execução volta a buscar desde a última sincronização
+	if _, err := adapter.FetchPRsForRepo("org", "api", start, now); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if len(client.calls) != 3 || client.calls[2][0].Before(now) {
+		t.Errorf("Expected a third incremental fetch starting after %v, got %v", now, client.calls)
+	}
+}
+
+// fakeReactionsClient devolve um comentário com reações e conta as chamadas de reações à API
+type fakeReactionsClient struct {
+	fakePRsClient
+	comments      []*github.IssueComment
+	reactions     []*github.Reaction
+	reactionCalls int
+}
+
+func (f *fakeReactionsClient) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	return f.comments, nil
+}
+
+func (f *fakeReactionsClient) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	f.reactionCalls++
+	return f.reactions, nil
+}
+
+func TestCachedReactionsKeepIDAndCreatedAt(t *testing.T) {
+	commentedAt := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	client := &fakeReactionsClient{
+		comments: []*github.IssueComment{{
+			ID:        github.Int64(100),
+			Body:      github.String("lgtm"),
+			User:      &github.User{Login: github.String("bob")},
+			CreatedAt: &github.Timestamp{Time: commentedAt},
+			UpdatedAt: &github.Timestamp{Time: commentedAt},
+		}},
+		reactions: []*github.Reaction{
+			{ID: github.Int64(9001), Content: github.String("+1"), User: &github.User{Login: github.String("carol")}, CreatedAt: &github.Timestamp{Time: commentedAt.Add(time.Hour)}},
+			{ID: github.Int64(9002), Content: github.String("heart"), User: &github.User{Login: github.String("dave")}, CreatedAt: &github.Timestamp{Time: commentedAt.Add(48 * time.Hour)}},
+		},
+	}
+	adapter := newTestCachedAdapter(t, client)
+	ctx := context.Background()
+
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+	if _, err := adapter.ListIssueCommentReactions(ctx, "org", "api", 100); err != nil {
+		t.Fatalf("ListIssueCommentReactions() cold error = %v", err)
+	}
+
+	cached, err := adapter.ListIssueCommentReactions(ctx, "org", "api", 100)
+	if err != nil {
+		t.Fatalf("ListIssueCommentReactions() warm error = %v", err)
+	}
+	if client.reactionCalls != 1 {
+		t.Fatalf("Expected the second call to be served from cache, got %d API calls", client.reactionCalls)
+	}
+
+	// O filtro de reações posteriores ao merge depende de CreatedAt; o cache deve devolver o mesmo valor da API
+	got := make(map[int64]time.Time)
+	for _, reaction := range cached {
+		got[reaction.GetID()] = reaction.GetCreatedAt().Time
+	}
+	for _, reaction := range client.reactions {
+		if createdAt, ok := got[reaction.GetID()]; !ok || !createdAt.Equal(reaction.GetCreatedAt().Time) {
+			t.Errorf("Expected reaction %d created at %v, got %v", reaction.GetID(), reaction.GetCreatedAt().Time, createdAt)
+		}
+	}
+}
+
+func TestCachedAdapterCountsUsage(t *testing.T) {
+	now := time.Now()
+	merged := now.AddDate(0, 0, -20)
+
+	// A lista de PRs ocupa duas páginas: cada uma é uma requisição à API
+	requests := 0
+	client := newTestGithubAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := []map[string]interface{}{testPR(2, merged, &merged)}
+		if r.URL.Query().Get("page") == "" {
+			page = []map[string]interface{}{testPR(1, merged, &merged)}
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	adapter := newTestCachedAdapter(t, client)
+
+	start, end := now.AddDate(0, 0, -30), now.AddDate(0, 0, -10)
+	for i := 0; i < 2; i++ {
+		if _, err := adapter.FetchPRsForRepo("org", "api", start, end); err != nil {
+			t.Fatalf("FetchPRsForRepo() error = %v", err)
+		}
+	}
+
+	usage := adapter.Usage()
+	if prs := usage.Cache[ResourcePRs]; prs.Hits != 1 || prs.Misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss for PRs, got %+v", prs)
+	}
+	if usage.APICalls != 2 || requests != 2 {
+		t.Errorf("Expected one API request per page, got %d (server saw %d)", usage.APICalls, requests)
+	}
+	if usage.RateLimit.Known {
+		t.Error("Expected unknown rate limit for responses without rate limit headers")
+	}
+}
+
+func TestCachedFetchPRsForRepoKeepsHostsApart(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	client := &fakePRsClient{prs: []*github.PullRequest{mergedPR(1, day(3))}}
+	adapter := newTestCachedAdapter(t, client)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := adapter.FetchPRsForRepo("org", "api", start, end); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	// O mesmo org/api em um GHES não reaproveita a sincronização do github.com
+	hosts := NewRepositoryHosts("")
+	hosts.Route("org", "api", "https://ghe.corp.com/api/v3/")
+	adapter.SetRepositoryHosts(hosts)
+	client.prs = []*github.PullRequest{mergedPR(7, day(5))}
+
+	prs, err := adapter.FetchPRsForRepo("org", "api", start, end)
+	if err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if len(client.calls) != 2 {
+		t.Errorf("Expected the API to be called for ghe.corp.com, got %d calls", len(client.calls))
+	}
+	if numbers := prNumbers(prs); len(numbers) != 1 || !numbers[7] {
+		t.Errorf("Expected only PR #7 from ghe.corp.com, got %v", numbers)
+	}
+}
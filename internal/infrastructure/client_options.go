@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// APIs disponíveis para buscar dados do GitHub
+const (
+	APIREST    = "rest"
+	APIGraphQL = "graphql"
+)
+
+// ClientOptions configura a criação de um GithubAdapter
+type ClientOptions struct {
+	API     string // rest (padrão) ou graphql
+	Token   string
+	BaseURL string                // URL do GitHub Enterprise Server (ex: https://ghe.corp.com); vazio = api.github.com
+	App     *GithubAppCredentials // Quando informado, autentica como instalação da GitHub App em vez do token
+}
+
+// NewGithubClientForAPI cria o GithubAdapter da API escolhida (rest ou graphql)
+func NewGithubClientForAPI(api, token string) (GithubAdapter, error) {
+	return NewGithubClientWithOptions(ClientOptions{API: api, Token: token})
+}
+
+// NewGithubClientWithOptions cria o GithubAdapter descrito pelas opções
+func NewGithubClientWithOptions(opts ClientOptions) (GithubAdapter, error) {
+	client, err := newGithubHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(opts.API)) {
+	case "", APIREST:
+		return &githubAdapter{client: client, limiter: NewRateLimiter()}, nil
+	case APIGraphQL:
+		return newGraphQLAdapter(client, NewRateLimiter()), nil
+	default:
+		return nil, fmt.Errorf("API inválida %q: use %s ou %s", opts.API, APIREST, APIGraphQL)
+	}
+}
+
+// newGithubHTTPClient cria o cliente go-github autenticado, apontando para o GHES quando BaseURL é informada
+func newGithubHTTPClient(opts ClientOptions) (*github.Client, error) {
+	var appTransport *appInstallationTransport
+	client := github.NewClient(nil).WithAuthToken(opts.Token)
+	if opts.App != nil {
+		appTransport = newAppInstallationTransport(opts.App)
+		client = github.NewClient(&http.Client{Transport: appTransport})
+	}
+
+	baseURL := strings.TrimSpace(opts.BaseURL)
+	if baseURL != "" {
+		if !strings.Contains(baseURL, "://") {
+			baseURL = "https://" + baseURL
+		}
+		baseURL = strings.TrimSuffix(baseURL, "/") + "/"
+
+		// O cliente enterprise acrescenta /api/v3/ e /api/uploads/ quando ausentes
+		enterpriseClient, err := client.WithEnterpriseURLs(baseURL, baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("URL do GitHub inválida %q: %v", opts.BaseURL, err)
+		}
+		client = enterpriseClient
+	}
+
+	// Os tokens de instalação são gerados na mesma instância que recebe as chamadas
+	if appTransport != nil {
+		appTransport.baseURL = client.BaseURL.String()
+	}
+	return client, nil
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewGithubClientWithOptionsEnterpriseURL(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		expected string
+	}{
+		{"", "https://api.github.com/"},
+		{"https://ghe.corp.com", "https://ghe.corp.com/api/v3/"},
+		{"ghe.corp.com", "https://ghe.corp.com/api/v3/"},
+		{"https://ghe.corp.com/api/v3/", "https://ghe.corp.com/api/v3/"},
+	}
+
+	for _, tt := range tests {
+		client, err := newGithubHTTPClient(ClientOptions{Token: "token", BaseURL: tt.baseURL})
+		if err != nil {
+			t.Fatalf("newGithubHTTPClient(%q) error = %v", tt.baseURL, err)
+		}
+		if got := client.BaseURL.String(); got != tt.expected {
+			t.Errorf("newGithubHTTPClient(%q) base URL = %s, expected %s", tt.baseURL, got, tt.expected)
+		}
+	}
+
+	if _, err := NewGithubClientWithOptions(ClientOptions{API: "soap"}); err == nil {
+		t.Error("Expected error for unknown API")
+	}
+}
+
+func TestRoutedGithubAdapterSendsEachRepoToItsHost(t *testing.T) {
+	// Servidor GHES: a API REST fica em /api/v3/
+	var enterpriseRequests []string
+	enterprise := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enterpriseRequests = append(enterpriseRequests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]interface{}{})
+	}))
+	t.Cleanup(enterprise.Close)
+
+	enterpriseClient, err := NewGithubClientWithOptions(ClientOptions{Token: "ghe-token", BaseURL: enterprise.URL})
+	if err != nil {
+		t.Fatalf("NewGithubClientWithOptions() error = %v", err)
+	}
+
+	defaultClient := &fakePRsClient{}
+	router := NewRoutedGithubAdapter(defaultClient)
+	router.Route("Corp", "Billing", enterpriseClient)
+
+	startDate := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)
+
+	if _, err := router.FetchPRsForRepo("corp", "billing", startDate, endDate); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if _, err := router.FetchPRsForRepo("org", "api", startDate, endDate); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	if len(enterpriseRequests) != 1 || enterpriseRequests[0] != "/api/v3/repos/corp/billing/pulls" {
+		t.Errorf("Expected one GHES request for corp/billing, got %v", enterpriseRequests)
+	}
+	if len(defaultClient.calls) != 1 {
+		t.Errorf("Expected org/api to use the default client, got %d calls", len(defaultClient.calls))
+	}
+}
+
+// rateLimitedClient é um cliente falso que informa um rate limit fixo
+type rateLimitedClient struct {
+	fakePRsClient
+	status RateLimitStatus
+}
+
+func (c *rateLimitedClient) RateLimit() RateLimitStatus {
+	return c.status
+}
+
+func TestRoutedGithubAdapterReportsLowestRateLimit(t *testing.T) {
+	reset := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	defaultClient := &rateLimitedClient{status: RateLimitStatus{Limit: 5000, Remaining: 4000, Known: true}}
+	enterpriseClient := &rateLimitedClient{status: RateLimitStatus{Limit: 5000, Remaining: 120, Reset: reset, Known: true}}
+	unknownClient := &rateLimitedClient{}
+
+	router := NewRoutedGithubAdapter(defaultClient)
+	router.Route("corp", "billing", enterpriseClient)
+	router.Route("corp", "payments", enterpriseClient)
+	router.Route("other", "api", unknownClient)
+
+	status := router.RateLimit()
+	if !status.Known || status.Remaining != 120 || !status.Reset.Equal(reset) {
+		t.Errorf("Expected the GHES rate limit (120 remaining), got %+v", status)
+	}
+
+	if status := NewRoutedGithubAdapter(&fakePRsClient{}).RateLimit(); status.Known {
+		t.Errorf("Expected an unknown rate limit without reporters, got %+v", status)
+	}
+}
@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// DiscoveredRepository é um repositório encontrado na listagem de uma organização
+type DiscoveredRepository struct {
+	Owner         string
+	Name          string
+	DefaultBranch string
+	Archived      bool
+	Topics        []string
+}
+
+// RepositoryDiscoverer é implementado pelos clientes capazes de listar os repositórios de uma organização
+type RepositoryDiscoverer interface {
+	ListOrgRepositories(ctx context.Context, org string) ([]*DiscoveredRepository, error)
+}
+
+// ListOrgRepositories lista todos os repositórios da organização
+func (c githubAdapter) ListOrgRepositories(ctx context.Context, org string) ([]*DiscoveredRepository, error) {
+	opts := &github.RepositoryListByOrgOptions{
+		Type:        "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var discovered []*DiscoveredRepository
+	for {
+		var repos []*github.Repository
+		var resp *github.Response
+		err := c.limiter.Do(ctx, func() (*github.Response, error) {
+			var err error
+			repos, resp, err = c.client.Repositories.ListByOrg(ctx, org, opts)
+			return resp, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar repositórios da organização %s: %v", org, err)
+		}
+
+		for _, repo := range repos {
+			discovered = append(discovered, &DiscoveredRepository{
+				Owner:         repo.GetOwner().GetLogin(),
+				Name:          repo.GetName(),
+				DefaultBranch: repo.GetDefaultBranch(),
+				Archived:      repo.GetArchived(),
+				Topics:        repo.Topics,
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return discovered, nil
+}
+
+// ListOrgRepositories usa a API REST: a listagem já é paginada e barata
+func (c *graphqlAdapter) ListOrgRepositories(ctx context.Context, org string) ([]*DiscoveredRepository, error) {
+	return listOrgRepositories(ctx, c.rest, org)
+}
+
+// ListOrgRepositories usa o cliente padrão (a organização é buscada no host padrão)
+func (r *RoutedGithubAdapter) ListOrgRepositories(ctx context.Context, org string) ([]*DiscoveredRepository, error) {
+	return listOrgRepositories(ctx, r.defaultClient, org)
+}
+
+// ListOrgRepositories não usa cache: a lista de repositórios muda com frequência e custa poucas chamadas
+func (c *CachedGithubAdapter) ListOrgRepositories(ctx context.Context, org string) ([]*DiscoveredRepository, error) {
+	return listOrgRepositories(ctx, c.githubClient, org)
+}
+
+// listOrgRepositories delega a listagem ao cliente, quando ele sabe listar repositórios
+func listOrgRepositories(ctx context.Context, client GithubAdapter, org string) ([]*DiscoveredRepository, error) {
+	discoverer, ok := client.(RepositoryDiscoverer)
+	if !ok {
+		return nil, fmt.Errorf("o cliente atual não lista repositórios de organizações")
+	}
+	return discoverer.ListOrgRepositories(ctx, org)
+}
+
+// DefaultBranchResolver é implementado pelos clientes capazes de informar a branch padrão de um repositório
+type DefaultBranchResolver interface {
+	GetDefaultBranch(ctx context.Context, owner, repo string) (string, error)
+}
+
+// GetDefaultBranch busca a branch padrão do repositório
+func (c githubAdapter) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	var repository *github.Repository
+	err := c.limiter.Do(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		repository, resp, err = c.client.Repositories.Get(ctx, owner, repo)
+		return resp, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar branch padrão de %s/%s: %v", owner, repo, err)
+	}
+	return repository.GetDefaultBranch(), nil
+}
+
+// GetDefaultBranch usa a API REST
+func (c *graphqlAdapter) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	return getDefaultBranch(ctx, c.rest, owner, repo)
+}
+
+// GetDefaultBranch usa o cliente do host do repositório
+func (r *RoutedGithubAdapter) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	return getDefaultBranch(ctx, r.clientFor(owner, repo), owner, repo)
+}
+
+// GetDefaultBranch consulta sempre a API (a branch padrão pode mudar) e salva o resultado para o modo offline
+func (c *CachedGithubAdapter) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	branch, err := getDefaultBranch(ctx, c.githubClient, owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.repoDB(owner, repo).SaveDefaultBranch(owner, repo, branch); err != nil {
+		fmt.Fprintf(c.progress(), "    ⚠️  Erro ao salvar branch padrão no cache: %v\n", err)
+	}
+	return branch, nil
+}
+
+// GetDefaultBranch responde com a branch padrão salva na última execução online
+func (o *OfflineGithubAdapter) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	branch, err := o.repoDB(owner, repo).GetDefaultBranch(owner, repo)
+	if err != nil {
+		return "", err
+	}
+	if branch == "" {
+		return "", fmt.Errorf("%w: branch padrão de %s/%s", ErrNotSynced, owner, repo)
+	}
+	return branch, nil
+}
+
+// getDefaultBranch delega a consulta ao cliente, quando ele sabe resolver a branch padrão
+func getDefaultBranch(ctx context.Context, client GithubAdapter, owner, repo string) (string, error) {
+	resolver, ok := client.(DefaultBranchResolver)
+	if !ok {
+		return "", fmt.Errorf("o cliente atual não resolve a branch padrão de %s/%s", owner, repo)
+	}
+	return resolver.GetDefaultBranch(ctx, owner, repo)
+}
@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL é o tempo padrão após o qual comentários e reações em cache são buscados novamente
+const DefaultCacheTTL = 7 * 24 * time.Hour
+
+// FreshnessPolicy decide quando comentários e reações em cache precisam ser buscados novamente.
+// Como a atividade posterior ao merge é ignorada na pontuação, os dados de PRs mergeados há tempo
+// suficiente podem ser congelados, enquanto os PRs da semana atual podem ser sempre atualizados
+type FreshnessPolicy struct {
+	TTL                time.Duration // Idade máxima do cache
+	FreezeAfterMerge   time.Duration // > 0: dados salvos depois de merge + N nunca expiram
+	RefreshCurrentWeek bool          // PRs mergeados na semana atual são sempre buscados na API
+	CurrentWeekStart   time.Time     // Início da semana atual (zero = últimos 7 dias)
+}
+
+// DefaultFreshnessPolicy retorna a política padrão: apenas o TTL de 7 dias
+func DefaultFreshnessPolicy() FreshnessPolicy {
+	return FreshnessPolicy{TTL: DefaultCacheTTL}
+}
+
+// IsStale indica se dados salvos em cachedAt, de um PR mergeado em mergedAt, estão desatualizados.
+// mergedAt zero (PR desconhecido no cache) aplica apenas o TTL
+func (p FreshnessPolicy) IsStale(cachedAt, mergedAt, now time.Time) bool {
+	if p.alwaysRefresh(mergedAt, now) {
+		return true
+	}
+
+	if p.FreezeAfterMerge > 0 && !mergedAt.IsZero() && !cachedAt.Before(mergedAt.Add(p.FreezeAfterMerge)) {
+		return false
+	}
+
+	return now.Sub(cachedAt) > p.ttl()
+}
+
+// alwaysRefresh indica se o PR foi mergeado na semana atual e a política pede atualização sempre
+func (p FreshnessPolicy) alwaysRefresh(mergedAt, now time.Time) bool {
+	if !p.RefreshCurrentWeek || mergedAt.IsZero() {
+		return false
+	}
+
+	weekStart := p.CurrentWeekStart
+	if weekStart.IsZero() {
+		weekStart = now.AddDate(0, 0, -7)
+	}
+	return !mergedAt.Before(weekStart)
+}
+
+// ttl retorna o TTL configurado ou o padrão
+func (p FreshnessPolicy) ttl() time.Duration {
+	if p.TTL <= 0 {
+		return DefaultCacheTTL
+	}
+	return p.TTL
+}
+
+// String descreve a política para o rodapé do relatório
+func (p FreshnessPolicy) String() string {
+	parts := []string{fmt.Sprintf("expira em %s", formatPolicyDuration(p.ttl()))}
+	if p.FreezeAfterMerge > 0 {
+		parts = append(parts, fmt.Sprintf("congelado %s após o merge", formatPolicyDuration(p.FreezeAfterMerge)))
+	}
+	if p.RefreshCurrentWeek {
+		parts = append(parts, "PRs da semana atual sempre atualizados")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatPolicyDuration exibe durações em dias quando exatas (ex: "7 dias") e em horas caso contrário
+func formatPolicyDuration(d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 dia"
+	case d%day == 0:
+		return fmt.Sprintf("%d dias", d/day)
+	default:
+		return d.String()
+	}
+}
+
+// FreshnessConfigurable é implementado pelos adaptadores com cache que aceitam uma política de validade
+type FreshnessConfigurable interface {
+	SetFreshnessPolicy(policy FreshnessPolicy)
+}
@@ -0,0 +1,155 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+func TestFreshnessPolicyIsStale(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC) // sexta-feira
+	weekStart := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	policy := FreshnessPolicy{TTL: 7 * day, FreezeAfterMerge: 3 * day, RefreshCurrentWeek: true, CurrentWeekStart: weekStart}
+
+	tests := []struct {
+		name     string
+		cachedAt time.Time
+		mergedAt time.Time
+		expected bool
+	}{
+		{"dentro do TTL", now.Add(-2 * day), time.Time{}, false},
+		{"TTL expirado sem PR conhecido", now.Add(-8 * day), time.Time{}, true},
+		{"congelado após o merge", now.AddDate(0, -6, 0), now.AddDate(0, -7, 0), false},
+		{"salvo antes do congelamento", now.Add(-8 * day), now.Add(-9 * day), true},
+		{"PR da semana atual", now.Add(-time.Hour), weekStart.Add(day), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.IsStale(tt.cachedAt, tt.mergedAt, now); got != tt.expected {
+				t.Errorf("IsStale() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := policy.String(); got != "expira em 7 dias, congelado 3 dias após o merge, PRs da semana atual sempre atualizados" {
+		t.Errorf("Unexpected policy description: %s", got)
+	}
+	if got := (FreshnessPolicy{}).String(); got != "expira em 7 dias" {
+		t.Errorf("Expected zero policy to fall back to the default TTL, got %s", got)
+	}
+}
+
+// countingCommentsClient devolve um comentário fixo e conta as buscas na API
+type countingCommentsClient struct {
+	fakePRsClient
+	calls int
+}
+
+func (f *countingCommentsClient) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	f.calls++
+	createdAt := &github.Timestamp{Time: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)}
+	return []*github.IssueComment{{
+		ID:        github.Int64(100),
+		Body:      github.String("lgtm"),
+		User:      &github.User{Login: github.String("bob")},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}}, nil
+}
+
+func TestCachedCommentsFollowFreshnessPolicy(t *testing.T) {
+	client := &countingCommentsClient{}
+	adapter := newTestCachedAdapter(t, client)
+	ctx := context.Background()
+
+	mergedAt := time.Now().AddDate(0, -2, 0)
+	if err := adapter.db.SaveMergedPRs([]*database.PRData{{
+		RepoOwner: "org", RepoName: "api", PRNumber: 1, Title: "PR 1", Username: "alice",
+		MergedAt: mergedAt, CachedAt: time.Now(),
+	}}); err != nil {
+		t.Fatalf("SaveMergedPRs() error = %v", err)
+	}
+
+	// Comentário salvo há 10 dias: expirado pelo TTL padrão
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+	comment, _ := adapter.db.GetComment("org", "api", 100)
+	comment.CachedAt = time.Now().AddDate(0, 0, -10)
+	if err := adapter.db.SaveComment(comment); err != nil {
+		t.Fatalf("SaveComment() error = %v", err)
+	}
+
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+	if client.calls != 2 {
+		t.Fatalf("Expected the default TTL to refetch stale comments, got %d calls", client.calls)
+	}
+
+	// Com congelamento após o merge, comentários salvos depois de merge + 3 dias não expiram
+	comment.CachedAt = time.Now().AddDate(0, 0, -10)
+	_ = adapter.db.SaveComment(comment)
+	adapter.SetFreshnessPolicy(FreshnessPolicy{TTL: DefaultCacheTTL, FreezeAfterMerge: 3 * 24 * time.Hour})
+
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+	if client.calls != 2 {
+		t.Errorf("Expected frozen comments to be served from cache, got %d calls", client.calls)
+	}
+}
+
+func TestRefreshedCommentsDropDeletedOnes(t *testing.T) {
+	commentedAt := time.Now().AddDate(0, 0, -1)
+	comment := func(id int64) *github.IssueComment {
+		return &github.IssueComment{
+			ID:        github.Int64(id),
+			Body:      github.String("lgtm"),
+			User:      &github.User{Login: github.String("bob")},
+			CreatedAt: &github.Timestamp{Time: commentedAt},
+			UpdatedAt: &github.Timestamp{Time: commentedAt},
+		}
+	}
+	client := &fakeReactionsClient{
+		comments:  []*github.IssueComment{comment(100), comment(101)},
+		reactions: []*github.Reaction{{ID: github.Int64(9001), Content: github.String("+1"), User: &github.User{Login: github.String("carol")}, CreatedAt: &github.Timestamp{Time: commentedAt}}},
+	}
+	adapter := newTestCachedAdapter(t, client)
+	adapter.SetFreshnessPolicy(FreshnessPolicy{TTL: DefaultCacheTTL, RefreshCurrentWeek: true})
+	ctx := context.Background()
+
+	if err := adapter.db.SaveMergedPRs([]*database.PRData{{
+		RepoOwner: "org", RepoName: "api", PRNumber: 1, Title: "PR 1", Username: "alice",
+		MergedAt: time.Now(), CachedAt: time.Now(),
+	}}); err != nil {
+		t.Fatalf("SaveMergedPRs() error = %v", err)
+	}
+
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+	if _, err := adapter.ListIssueCommentReactions(ctx, "org", "api", 101); err != nil {
+		t.Fatalf("ListIssueCommentReactions() error = %v", err)
+	}
+
+	// O comentário 101 foi apagado no GitHub; a atualização da semana atual deve removê-lo do cache
+	client.comments = []*github.IssueComment{comment(100)}
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+
+	cached, err := adapter.db.GetCommentsByPRAndType("org", "api", 1, "issue")
+	if err != nil || len(cached) != 1 || cached[0].CommentID != 100 {
+		t.Errorf("Expected only comment 100 cached after refresh, got %v (%v)", cached, err)
+	}
+	if reactions, _ := adapter.db.GetReactions(101); len(reactions) != 0 {
+		t.Errorf("Expected reactions of the deleted comment to be removed, got %d", len(reactions))
+	}
+}
@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/go-github/v70/github"
@@ -25,21 +26,39 @@ type CacheableGithubAdapter interface {
 }
 
 type githubAdapter struct {
-	client *github.Client
+	client  *github.Client
+	limiter *RateLimiter
 }
 
 func NewGithubClient(token string) GithubAdapter {
 	client := github.NewClient(nil).WithAuthToken(token)
 
-	return &githubAdapter{client: client}
+	return &githubAdapter{client: client, limiter: NewRateLimiter()}
+}
+
+// RateLimit retorna o último estado conhecido do rate limit da API
+func (c githubAdapter) RateLimit() RateLimitStatus {
+	return c.limiter.RateLimit()
+}
+
+// SetProgressOutput define onde as mensagens de progresso do cliente são escritas
+func (c githubAdapter) SetProgressOutput(w io.Writer) {
+	c.limiter.SetProgressOutput(w)
+}
+
+// APIUsage retorna as requisições feitas à API por este cliente
+func (c githubAdapter) APIUsage() APIUsage {
+	return c.limiter.APIUsage()
 }
 
 func (c githubAdapter) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
 	ctx := context.Background()
 
+	// Ordena pela última atualização: um PR mergeado no período foi atualizado no merge ou depois,
+	// então ao chegar em PRs atualizados antes do início do período não há mais o que buscar
 	opts := &github.PullRequestListOptions{
 		State:     "closed",
-		Sort:      "created",
+		Sort:      "updated",
 		Direction: "desc",
 		ListOptions: github.ListOptions{
 			PerPage: 100,
@@ -50,26 +69,29 @@ func (c githubAdapter) FetchPRsForRepo(owner, name string, startDate, endDate ti
 	shouldStop := false
 
 	for !shouldStop {
-		prs, resp, err := c.client.PullRequests.List(ctx, owner, name, opts)
+		var prs []*github.PullRequest
+		var resp *github.Response
+		err := c.limiter.Do(ctx, func() (*github.Response, error) {
+			var err error
+			prs, resp, err = c.client.PullRequests.List(ctx, owner, name, opts)
+			return resp, err
+		})
 		if err != nil {
 			return nil, fmt.Errorf("erro ao buscar PRs: %v", err)
 		}
 
 		for _, pr := range prs {
-			// fmt.Println("PR = ", pr.GetTitle())
-			// fmt.Println("PR mergedAt ", pr.MergedAt)
-			// fmt.Println("PR updatedAt ", pr.UpdatedAt)
+			if pr.UpdatedAt != nil && pr.UpdatedAt.Time.Before(startDate) {
+				// Os próximos PRs foram atualizados ainda antes; nenhum deles pode ter sido mergeado no período
+				shouldStop = true
+				break
+			}
+
 			if pr.MergedAt == nil {
 				continue // Pula PRs não mergeados
 			}
 
 			mergedAt := pr.MergedAt.Time
-			// if mergedAt.Before(startDate) {
-			// 	// Se chegamos a PRs anteriores ao período, paramos de buscar mais páginas
-			// 	shouldStop = true
-			// 	break
-			// }
-
 			if mergedAt.After(startDate) && mergedAt.Before(endDate.Add(24*time.Hour)) {
 				repoPRs = append(repoPRs, pr)
 			}
@@ -81,13 +103,19 @@ func (c githubAdapter) FetchPRsForRepo(owner, name string, startDate, endDate ti
 		opts.Page = resp.NextPage
 	}
 
-	fmt.Printf("    ✅ %d PRs encontrados em %s/%s\n", len(repoPRs), owner, name)
+	fmt.Fprintf(c.limiter.progress(), "    ✅ %d PRs encontrados em %s/%s\n", len(repoPRs), owner, name)
 	return repoPRs, nil
 }
 
 // GetPR busca um PR específico pelo número
 func (c githubAdapter) GetPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
-	pr, _, err := c.client.PullRequests.Get(ctx, owner, repo, prNumber)
+	var pr *github.PullRequest
+	err := c.limiter.Do(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		pr, resp, err = c.client.PullRequests.Get(ctx, owner, repo, prNumber)
+		return resp, err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar PR #%d: %v", prNumber, err)
 	}
@@ -95,12 +123,24 @@ func (c githubAdapter) GetPR(ctx context.Context, owner, repo string, prNumber i
 }
 
 func (c githubAdapter) ListIssueCommentReactions(ctx context.Context, owner, repo string, issueNumber int64) ([]*github.Reaction, error) {
-	reactions, _, err := c.client.Reactions.ListIssueCommentReactions(ctx, owner, repo, issueNumber, nil)
+	var reactions []*github.Reaction
+	err := c.limiter.Do(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		reactions, resp, err = c.client.Reactions.ListIssueCommentReactions(ctx, owner, repo, issueNumber, nil)
+		return resp, err
+	})
 	return reactions, err
 }
 
 func (c githubAdapter) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
-	reactions, _, err := c.client.Reactions.ListPullRequestCommentReactions(ctx, owner, repo, commentID, nil)
+	var reactions []*github.Reaction
+	err := c.limiter.Do(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		reactions, resp, err = c.client.Reactions.ListPullRequestCommentReactions(ctx, owner, repo, commentID, nil)
+		return resp, err
+	})
 	return reactions, err
 }
 
@@ -112,7 +152,13 @@ func (c githubAdapter) ListPRComments(ctx context.Context, owner, repo string, p
 		},
 	}
 	for {
-		comm, resp, err := c.client.Issues.ListComments(ctx, owner, repo, prNumber, reviewOpts)
+		var comm []*github.IssueComment
+		var resp *github.Response
+		err := c.limiter.Do(ctx, func() (*github.Response, error) {
+			var err error
+			comm, resp, err = c.client.Issues.ListComments(ctx, owner, repo, prNumber, reviewOpts)
+			return resp, err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -133,7 +179,13 @@ func (c githubAdapter) ListPRReviewComments(ctx context.Context, owner, repo str
 		},
 	}
 	for {
-		comm, resp, err := c.client.PullRequests.ListComments(ctx, owner, repo, prNumber, reviewOpts)
+		var comm []*github.PullRequestComment
+		var resp *github.Response
+		err := c.limiter.Do(ctx, func() (*github.Response, error) {
+			var err error
+			comm, resp, err = c.client.PullRequests.ListComments(ctx, owner, repo, prNumber, reviewOpts)
+			return resp, err
+		})
 		if err != nil {
 			return nil, err
 		}
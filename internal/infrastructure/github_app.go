@@ -0,0 +1,190 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GithubAppCredentials identifica a instalação de uma GitHub App usada para autenticar as chamadas
+type GithubAppCredentials struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKey     *rsa.PrivateKey
+}
+
+// LoadGithubAppCredentials lê a chave privada (PEM) da GitHub App e monta as credenciais
+func LoadGithubAppCredentials(appID, installationID int64, privateKeyPath string) (*GithubAppCredentials, error) {
+	if appID <= 0 || installationID <= 0 {
+		return nil, fmt.Errorf("app ID e installation ID da GitHub App são obrigatórios")
+	}
+
+	data, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler chave privada da GitHub App: %v", err)
+	}
+
+	key, err := parseRSAPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GithubAppCredentials{AppID: appID, InstallationID: installationID, PrivateKey: key}, nil
+}
+
+// parseRSAPrivateKey aceita chaves PKCS#1 (formato gerado pelo GitHub) e PKCS#8
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("chave privada da GitHub App inválida: PEM não encontrado")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("chave privada da GitHub App inválida: %v", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("chave privada da GitHub App inválida: a chave deve ser RSA")
+	}
+	return key, nil
+}
+
+// appJWT gera o JWT (RS256) que autentica a própria GitHub App; o GitHub aceita no máximo 10 minutos de validade
+func (c *GithubAppCredentials) appJWT(now time.Time) (string, error) {
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(), // Tolera diferenças de relógio com o GitHub
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(c.AppID, 10),
+	}
+
+	encode := func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	}
+
+	encodedHeader, err := encode(header)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar JWT da GitHub App: %v", err)
+	}
+	encodedClaims, err := encode(claims)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar JWT da GitHub App: %v", err)
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.PrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("erro ao assinar JWT da GitHub App: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// installationTokenRefreshMargin renova o token um pouco antes de expirar para não falhar no meio de uma chamada
+const installationTokenRefreshMargin = 5 * time.Minute
+
+// appInstallationTransport autentica as requisições com tokens de instalação da GitHub App,
+// gerando um novo token automaticamente quando o atual está para expirar
+type appInstallationTransport struct {
+	credentials *GithubAppCredentials
+	baseURL     string            // URL base da API REST (github.com ou GHES), terminada em /
+	base        http.RoundTripper // transporte usado nas chamadas
+	now         func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newAppInstallationTransport(credentials *GithubAppCredentials) *appInstallationTransport {
+	return &appInstallationTransport{
+		credentials: credentials,
+		baseURL:     "https://api.github.com/",
+		base:        http.DefaultTransport,
+		now:         time.Now,
+	}
+}
+
+// RoundTrip implementa http.RoundTripper
+func (t *appInstallationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.installationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// RoundTrip não deve alterar a requisição original
+	authenticated := req.Clone(req.Context())
+	authenticated.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(authenticated)
+}
+
+// installationToken retorna o token atual ou gera um novo quando ele está expirando
+func (t *appInstallationTransport) installationToken() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && t.now().Add(installationTokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	jwt, err := t.credentials.appJWT(t.now())
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%d/access_tokens", t.baseURL, t.credentials.InstallationID)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(nil))
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar requisição do token de instalação: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar token de instalação da GitHub App: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		var body bytes.Buffer
+		_, _ = body.ReadFrom(resp.Body)
+		return "", fmt.Errorf("erro ao gerar token de instalação da GitHub App: %s %s",
+			resp.Status, strings.TrimSpace(body.String()))
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("erro ao decodificar token de instalação da GitHub App: %v", err)
+	}
+
+	t.token = result.Token
+	t.expiresAt = result.ExpiresAt
+	return t.token, nil
+}
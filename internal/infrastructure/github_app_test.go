@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestAppKey gera uma chave RSA e a grava em PEM (PKCS#1, como as chaves baixadas do GitHub)
+func writeTestAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "app.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return key, path
+}
+
+// verifyTestJWT valida a assinatura RS256 e retorna as claims do JWT
+func verifyTestJWT(t *testing.T, jwt string, key *rsa.PublicKey) map[string]interface{} {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Invalid JWT: %s", jwt)
+	}
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("Invalid JWT signature: %v", err)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("Invalid JWT claims: %v", err)
+	}
+	return claims
+}
+
+func TestGithubAppInstallationTokenIsMintedAndRefreshed(t *testing.T) {
+	key, keyPath := writeTestAppKey(t)
+	credentials, err := LoadGithubAppCredentials(123, 42, keyPath)
+	if err != nil {
+		t.Fatalf("LoadGithubAppCredentials() error = %v", err)
+	}
+
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	minted := 0
+	var apiAuthorizations []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v3/app/installations/42/access_tokens" {
+			claims := verifyTestJWT(t, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &key.PublicKey)
+			if claims["iss"] != "123" {
+				t.Errorf("Expected iss 123, got %v", claims["iss"])
+			}
+
+			minted++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"token":      fmt.Sprintf("installation-token-%d", minted),
+				"expires_at": now.Add(time.Hour).Format(time.RFC3339),
+			})
+			return
+		}
+
+		apiAuthorizations = append(apiAuthorizations, r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode([]interface{}{})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newGithubHTTPClient(ClientOptions{BaseURL: server.URL, App: credentials})
+	if err != nil {
+		t.Fatalf("newGithubHTTPClient() error = %v", err)
+	}
+	transport := client.Client().Transport.(*appInstallationTransport)
+	transport.now = func() time.Time { return now }
+
+	adapter := &githubAdapter{client: client, limiter: NewRateLimiter()}
+	fetch := func() {
+		if _, err := adapter.FetchPRsForRepo("org", "api", now.AddDate(0, 0, -7), now); err != nil {
+			t.Fatalf("FetchPRsForRepo() error = %v", err)
+		}
+	}
+
+	fetch()
+	fetch()
+	if minted != 1 {
+		t.Errorf("Expected the installation token to be reused, minted %d", minted)
+	}
+
+	// Perto de expirar (dentro da margem de renovação), um novo token é gerado
+	now = now.Add(57 * time.Minute)
+	fetch()
+	if minted != 2 {
+		t.Errorf("Expected the installation token to be refreshed, minted %d", minted)
+	}
+
+	expected := []string{"token installation-token-1", "token installation-token-1", "token installation-token-2"}
+	if fmt.Sprint(apiAuthorizations) != fmt.Sprint(expected) {
+		t.Errorf("Expected authorizations %v, got %v", expected, apiAuthorizations)
+	}
+}
+
+func TestLoadGithubAppCredentialsValidation(t *testing.T) {
+	_, keyPath := writeTestAppKey(t)
+
+	if _, err := LoadGithubAppCredentials(0, 42, keyPath); err == nil {
+		t.Error("Expected error without app ID")
+	}
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	_ = os.WriteFile(invalidPath, []byte("not a key"), 0600)
+	if _, err := LoadGithubAppCredentials(123, 42, invalidPath); err == nil {
+		t.Error("Expected error for invalid PEM")
+	}
+}
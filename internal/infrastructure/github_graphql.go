@@ -0,0 +1,514 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// Limites de cada página das consultas. A lista de PRs não traz conexões aninhadas, então pode ter
+// páginas maiores; os comentários são consultados por PR com limites pequenos, e listas truncadas
+// são completadas pela API REST
+const (
+	graphqlPRsPerPage        = 50
+	graphqlCommentsPerPR     = 30
+	graphqlThreadsPerPR      = 15
+	graphqlCommentsPerThread = 10
+	graphqlReactionsPerItem  = 10
+)
+
+// graphqlCommentFields são os campos de um comentário (issue ou review) com suas reações
+var graphqlCommentFields = fmt.Sprintf(`
+	databaseId
+	body
+	createdAt
+	updatedAt
+	author { login }
+	reactions(first: %d) {
+		pageInfo { hasNextPage }
+		nodes { databaseId content createdAt user { login } }
+	}`, graphqlReactionsPerItem)
+
+// graphqlPRFields são os campos de um PR usados na análise, sem conexões aninhadas
+const graphqlPRFields = `
+	number
+	title
+	mergedAt
+	updatedAt
+	baseRefName
+	author { login }`
+
+// graphqlPRCommentFields são os comentários, threads de revisão e reações de um PR
+var graphqlPRCommentFields = fmt.Sprintf(`
+	comments(first: %d) {
+		pageInfo { hasNextPage }
+		nodes { %s }
+	}
+	reviewThreads(first: %d) {
+		pageInfo { hasNextPage }
+		nodes {
+			comments(first: %d) {
+				pageInfo { hasNextPage }
+				nodes { %s }
+			}
+		}
+	}`, graphqlCommentsPerPR, graphqlCommentFields, graphqlThreadsPerPR, graphqlCommentsPerThread, graphqlCommentFields)
+
+var graphqlMergedPRsQuery = fmt.Sprintf(`
+query($owner: String!, $name: String!, $after: String) {
+	repository(owner: $owner, name: $name) {
+		pullRequests(states: MERGED, first: %d, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			pageInfo { hasNextPage endCursor }
+			nodes { %s }
+		}
+	}
+}`, graphqlPRsPerPage, graphqlPRFields)
+
+var graphqlPRQuery = fmt.Sprintf(`
+query($owner: String!, $name: String!, $number: Int!) {
+	repository(owner: $owner, name: $name) {
+		pullRequest(number: $number) { %s }
+	}
+}`, graphqlPRFields)
+
+var graphqlPRCommentsQuery = fmt.Sprintf(`
+query($owner: String!, $name: String!, $number: Int!) {
+	repository(owner: $owner, name: $name) {
+		pullRequest(number: $number) { %s }
+	}
+}`, graphqlPRCommentFields)
+
+// Estruturas da resposta GraphQL
+type gqlPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type gqlActor struct {
+	Login string `json:"login"`
+}
+
+type gqlReaction struct {
+	DatabaseID int64     `json:"databaseId"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"createdAt"`
+	User       *gqlActor `json:"user"`
+}
+
+type gqlComment struct {
+	DatabaseID int64     `json:"databaseId"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Author     *gqlActor `json:"author"`
+	Reactions  struct {
+		PageInfo gqlPageInfo   `json:"pageInfo"`
+		Nodes    []gqlReaction `json:"nodes"`
+	} `json:"reactions"`
+}
+
+type gqlCommentConnection struct {
+	PageInfo gqlPageInfo  `json:"pageInfo"`
+	Nodes    []gqlComment `json:"nodes"`
+}
+
+type gqlPullRequest struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	MergedAt    *time.Time `json:"mergedAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	BaseRefName string     `json:"baseRefName"`
+	Author      *gqlActor  `json:"author"`
+}
+
+type gqlPullRequestComments struct {
+	Comments      gqlCommentConnection `json:"comments"`
+	ReviewThreads struct {
+		PageInfo gqlPageInfo `json:"pageInfo"`
+		Nodes    []struct {
+			Comments gqlCommentConnection `json:"comments"`
+		} `json:"nodes"`
+	} `json:"reviewThreads"`
+}
+
+type gqlError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+type gqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []gqlError      `json:"errors"`
+}
+
+// graphqlReactionContents converte os valores de ReactionContent do GraphQL para os usados pela API REST
+var graphqlReactionContents = map[string]string{
+	"THUMBS_UP":   "+1",
+	"THUMBS_DOWN": "-1",
+	"LAUGH":       "laugh",
+	"HOORAY":      "hooray",
+	"CONFUSED":    "confused",
+	"HEART":       "heart",
+	"ROCKET":      "rocket",
+	"EYES":        "eyes",
+}
+
+// graphqlPRComments guarda os comentários de um PR já obtidos via GraphQL
+type graphqlPRComments struct {
+	issueComments  []*github.IssueComment
+	reviewComments []*github.PullRequestComment
+	issueComplete  bool // false quando a consulta não trouxe todos os issue comments
+	reviewComplete bool // false quando a consulta não trouxe todos os review comments
+}
+
+// graphqlReactionKey identifica as reações de um comentário (IDs de issue e review comments são independentes)
+type graphqlReactionKey struct {
+	kind string // "issue" ou "review"
+	id   int64
+}
+
+// graphqlAdapter implementa GithubAdapter com a API GraphQL: a lista de PRs vem em consultas paginadas
+// e, para cada PR do período, comentários, threads de revisão e reações vêm em uma única consulta,
+// memorizada para as chamadas seguintes. Quando uma lista é truncada pelos limites da consulta, o item
+// é buscado pela API REST.
+type graphqlAdapter struct {
+	client  *github.Client
+	limiter *RateLimiter
+	rest    GithubAdapter
+
+	mu         sync.Mutex
+	prComments map[string]*graphqlPRComments             // "owner/repo#número" -> comentários
+	reactions  map[graphqlReactionKey][]*github.Reaction // apenas listas completas
+}
+
+// NewGithubGraphQLClient cria um GithubAdapter que usa a API GraphQL do GitHub
+func NewGithubGraphQLClient(token string) GithubAdapter {
+	client := github.NewClient(nil).WithAuthToken(token)
+	return newGraphQLAdapter(client, NewRateLimiter())
+}
+
+func newGraphQLAdapter(client *github.Client, limiter *RateLimiter) *graphqlAdapter {
+	return &graphqlAdapter{
+		client:     client,
+		limiter:    limiter,
+		rest:       &githubAdapter{client: client, limiter: limiter},
+		prComments: make(map[string]*graphqlPRComments),
+		reactions:  make(map[graphqlReactionKey][]*github.Reaction),
+	}
+}
+
+// RateLimit retorna o último estado conhecido do rate limit da API
+func (c *graphqlAdapter) RateLimit() RateLimitStatus {
+	return c.limiter.RateLimit()
+}
+
+// SetProgressOutput define onde as mensagens de progresso do cliente são escritas (o limiter é compartilhado)
+func (c *graphqlAdapter) SetProgressOutput(w io.Writer) {
+	c.limiter.SetProgressOutput(w)
+}
+
+// APIUsage retorna as requisições GraphQL e REST feitas por este cliente (o limiter é compartilhado)
+func (c *graphqlAdapter) APIUsage() APIUsage {
+	return c.limiter.APIUsage()
+}
+
+// graphqlEndpoint retorna a URL do endpoint GraphQL a partir da URL base da API REST
+func graphqlEndpoint(client *github.Client) string {
+	base := client.BaseURL.String()
+	// GitHub Enterprise Server: REST em /api/v3/, GraphQL em /api/graphql
+	if strings.HasSuffix(base, "/api/v3/") {
+		return strings.TrimSuffix(base, "v3/") + "graphql"
+	}
+	return base + "graphql"
+}
+
+// query executa uma consulta GraphQL e decodifica o campo data em out
+func (c *graphqlAdapter) query(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	payload := map[string]interface{}{"query": query, "variables": variables}
+
+	var result gqlResponse
+	err := c.limiter.Do(ctx, func() (*github.Response, error) {
+		// A requisição é recriada a cada tentativa: o corpo é consumido no envio
+		req, err := c.client.NewRequest("POST", graphqlEndpoint(c.client), payload)
+		if err != nil {
+			return nil, err
+		}
+		result = gqlResponse{}
+		return c.client.Do(ctx, req, &result)
+	})
+	if err != nil {
+		return fmt.Errorf("erro na consulta GraphQL: %v", err)
+	}
+
+	if len(result.Errors) > 0 {
+		var messages []string
+		for _, e := range result.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("erro na consulta GraphQL: %s", strings.Join(messages, "; "))
+	}
+
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("erro ao decodificar resposta GraphQL: %v", err)
+	}
+	return nil
+}
+
+func (c *graphqlAdapter) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
+	ctx := context.Background()
+
+	var repoPRs []*github.PullRequest
+	var after interface{} // nil na primeira página
+
+	for {
+		var data struct {
+			Repository *struct {
+				PullRequests struct {
+					PageInfo gqlPageInfo      `json:"pageInfo"`
+					Nodes    []gqlPullRequest `json:"nodes"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+
+		variables := map[string]interface{}{"owner": owner, "name": name, "after": after}
+		if err := c.query(ctx, graphqlMergedPRsQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("erro ao buscar PRs: %v", err)
+		}
+		if data.Repository == nil {
+			return nil, fmt.Errorf("erro ao buscar PRs: repositório %s/%s não encontrado", owner, name)
+		}
+
+		// Mesma lógica da API REST: ordenados pela última atualização, os PRs atualizados antes
+		// do início do período encerram a busca
+		shouldStop := false
+		for i := range data.Repository.PullRequests.Nodes {
+			pr := &data.Repository.PullRequests.Nodes[i]
+			if pr.UpdatedAt.Before(startDate) {
+				shouldStop = true
+				break
+			}
+
+			if pr.MergedAt == nil {
+				continue
+			}
+			if pr.MergedAt.After(startDate) && pr.MergedAt.Before(endDate.Add(24*time.Hour)) {
+				repoPRs = append(repoPRs, pr.toGithub(owner, name))
+			}
+		}
+
+		pageInfo := data.Repository.PullRequests.PageInfo
+		if shouldStop || !pageInfo.HasNextPage {
+			break
+		}
+		after = pageInfo.EndCursor
+	}
+
+	return repoPRs, nil
+}
+
+func (c *graphqlAdapter) GetPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	var data struct {
+		Repository *struct {
+			PullRequest *gqlPullRequest `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	variables := map[string]interface{}{"owner": owner, "name": repo, "number": prNumber}
+	if err := c.query(ctx, graphqlPRQuery, variables, &data); err != nil {
+		return nil, fmt.Errorf("erro ao buscar PR #%d: %v", prNumber, err)
+	}
+	if data.Repository == nil || data.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("erro ao buscar PR #%d: não encontrado em %s/%s", prNumber, owner, repo)
+	}
+
+	// Apenas PRs mergeados entram na análise; o cache guarda a data do merge
+	pr := data.Repository.PullRequest
+	if pr.MergedAt == nil {
+		return nil, fmt.Errorf("erro ao buscar PR #%d: %s/%s#%d não foi mergeado", prNumber, owner, repo, prNumber)
+	}
+	return pr.toGithub(owner, repo), nil
+}
+
+func (c *graphqlAdapter) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	comments, err := c.commentsFor(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !comments.issueComplete {
+		return c.rest.ListPRComments(ctx, owner, repo, prNumber)
+	}
+	return comments.issueComments, nil
+}
+
+func (c *graphqlAdapter) ListPRReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.PullRequestComment, error) {
+	comments, err := c.commentsFor(ctx, owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !comments.reviewComplete {
+		return c.rest.ListPRReviewComments(ctx, owner, repo, prNumber)
+	}
+	return comments.reviewComments, nil
+}
+
+func (c *graphqlAdapter) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	if reactions, ok := c.memorizedReactions("issue", commentID); ok {
+		return reactions, nil
+	}
+	return c.rest.ListIssueCommentReactions(ctx, owner, repo, commentID)
+}
+
+func (c *graphqlAdapter) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	if reactions, ok := c.memorizedReactions("review", commentID); ok {
+		return reactions, nil
+	}
+	return c.rest.ListPullRequestCommentReactions(ctx, owner, repo, commentID)
+}
+
+// fetchComments busca os comentários e reações de um PR e memoriza o resultado
+func (c *graphqlAdapter) fetchComments(ctx context.Context, owner, repo string, prNumber int) error {
+	var data struct {
+		Repository *struct {
+			PullRequest *gqlPullRequestComments `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	variables := map[string]interface{}{"owner": owner, "name": repo, "number": prNumber}
+	if err := c.query(ctx, graphqlPRCommentsQuery, variables, &data); err != nil {
+		return fmt.Errorf("erro ao buscar comentários do PR #%d: %v", prNumber, err)
+	}
+	if data.Repository == nil || data.Repository.PullRequest == nil {
+		return fmt.Errorf("erro ao buscar comentários do PR #%d: não encontrado em %s/%s", prNumber, owner, repo)
+	}
+
+	c.remember(owner, repo, prNumber, data.Repository.PullRequest)
+	return nil
+}
+
+// commentsFor retorna os comentários memorizados do PR, consultando-os quando ainda não foram obtidos
+func (c *graphqlAdapter) commentsFor(ctx context.Context, owner, repo string, prNumber int) (*graphqlPRComments, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)
+
+	c.mu.Lock()
+	comments, ok := c.prComments[key]
+	c.mu.Unlock()
+	if ok {
+		return comments, nil
+	}
+
+	if err := c.fetchComments(ctx, owner, repo, prNumber); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prComments[key], nil
+}
+
+// memorizedReactions retorna as reações memorizadas de um comentário, quando completas
+func (c *graphqlAdapter) memorizedReactions(kind string, commentID int64) ([]*github.Reaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reactions, ok := c.reactions[graphqlReactionKey{kind: kind, id: commentID}]
+	return reactions, ok
+}
+
+// remember memoriza os comentários e reações de um PR retornados pela consulta
+func (c *graphqlAdapter) remember(owner, repo string, prNumber int, pr *gqlPullRequestComments) {
+	comments := &graphqlPRComments{
+		issueComments:  []*github.IssueComment{},
+		reviewComments: []*github.PullRequestComment{},
+		issueComplete:  !pr.Comments.PageInfo.HasNextPage,
+		reviewComplete: !pr.ReviewThreads.PageInfo.HasNextPage,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, comment := range pr.Comments.Nodes {
+		comments.issueComments = append(comments.issueComments, &github.IssueComment{
+			ID:        github.Int64(comment.DatabaseID),
+			Body:      github.String(comment.Body),
+			User:      comment.Author.toGithub(),
+			CreatedAt: &github.Timestamp{Time: comment.CreatedAt},
+			UpdatedAt: &github.Timestamp{Time: comment.UpdatedAt},
+		})
+		c.rememberReactions("issue", &comment)
+	}
+
+	for _, thread := range pr.ReviewThreads.Nodes {
+		if thread.Comments.PageInfo.HasNextPage {
+			comments.reviewComplete = false
+		}
+		for _, comment := range thread.Comments.Nodes {
+			comments.reviewComments = append(comments.reviewComments, &github.PullRequestComment{
+				ID:        github.Int64(comment.DatabaseID),
+				Body:      github.String(comment.Body),
+				User:      comment.Author.toGithub(),
+				CreatedAt: &github.Timestamp{Time: comment.CreatedAt},
+				UpdatedAt: &github.Timestamp{Time: comment.UpdatedAt},
+			})
+			c.rememberReactions("review", &comment)
+		}
+	}
+
+	c.prComments[fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)] = comments
+}
+
+// rememberReactions memoriza as reações de um comentário; listas truncadas ficam para a API REST.
+// Deve ser chamada com o mutex travado.
+func (c *graphqlAdapter) rememberReactions(kind string, comment *gqlComment) {
+	if comment.Reactions.PageInfo.HasNextPage {
+		return
+	}
+
+	reactions := []*github.Reaction{}
+	for _, reaction := range comment.Reactions.Nodes {
+		content, ok := graphqlReactionContents[reaction.Content]
+		if !ok {
+			content = strings.ToLower(reaction.Content)
+		}
+		reactions = append(reactions, &github.Reaction{
+			ID:        github.Int64(reaction.DatabaseID),
+			Content:   github.String(content),
+			User:      reaction.User.toGithub(),
+			CreatedAt: &github.Timestamp{Time: reaction.CreatedAt},
+		})
+	}
+	c.reactions[graphqlReactionKey{kind: kind, id: comment.DatabaseID}] = reactions
+}
+
+// toGithub converte o autor GraphQL; contas removidas aparecem como "ghost", como na API REST
+func (a *gqlActor) toGithub() *github.User {
+	if a == nil {
+		return &github.User{Login: github.String("ghost")}
+	}
+	return &github.User{Login: github.String(a.Login)}
+}
+
+// toGithub converte o PR GraphQL para github.PullRequest com os campos usados na análise
+func (pr *gqlPullRequest) toGithub(owner, name string) *github.PullRequest {
+	result := &github.PullRequest{
+		Number:    github.Int(pr.Number),
+		Title:     github.String(pr.Title),
+		User:      pr.Author.toGithub(),
+		UpdatedAt: &github.Timestamp{Time: pr.UpdatedAt},
+		Base: &github.PullRequestBranch{
+			Ref: github.String(pr.BaseRefName),
+			Repo: &github.Repository{
+				Name:  github.String(name),
+				Owner: &github.User{Login: github.String(owner)},
+			},
+		},
+	}
+	if pr.MergedAt != nil {
+		result.MergedAt = &github.Timestamp{Time: *pr.MergedAt}
+	}
+	return result
+}
@@ -0,0 +1,247 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// graphqlFixtureServer serve as respostas gravadas em testdata/graphql e registra as requisições recebidas
+type graphqlFixtureServer struct {
+	t        *testing.T
+	requests []string
+}
+
+func (s *graphqlFixtureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var fixture string
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/graphql":
+		var payload struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			s.t.Errorf("Invalid GraphQL payload: %v", err)
+		}
+
+		// A lista de PRs não pode trazer conexões aninhadas: comentários e reações são consultados por PR
+		if _, isList := payload.Variables["after"]; isList && strings.Contains(payload.Query, "comments(") {
+			s.t.Errorf("Expected the PR list query without nested comments, got %s", payload.Query)
+		}
+
+		switch {
+		case payload.Variables["number"] != nil && strings.Contains(payload.Query, "reviewThreads"):
+			fixture = fmt.Sprintf("pr_%v_comments.json", payload.Variables["number"])
+		case payload.Variables["number"] != nil:
+			fixture = fmt.Sprintf("pr_%v.json", payload.Variables["number"])
+		case payload.Variables["after"] == nil:
+			fixture = "prs_page1.json"
+		case payload.Variables["after"] == "cursor-1":
+			fixture = "prs_page2.json"
+		default:
+			s.t.Errorf("Unexpected page requested after %v", payload.Variables["after"])
+			http.NotFound(w, r)
+			return
+		}
+		s.requests = append(s.requests, "graphql:"+strings.TrimSuffix(fixture, ".json"))
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/org/api/issues/comments/"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/org/api/issues/comments/"), "/reactions")
+		fixture = "rest_reactions_" + id + ".json"
+		s.requests = append(s.requests, "rest:reactions_"+id)
+
+	default:
+		s.t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := os.ReadFile(filepath.Join("testdata", "graphql", fixture))
+	if err != nil {
+		s.t.Errorf("Missing fixture %s: %v", fixture, err)
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func newTestGraphQLAdapter(t *testing.T) (*graphqlAdapter, *graphqlFixtureServer) {
+	t.Helper()
+
+	fixtures := &graphqlFixtureServer{t: t}
+	server := httptest.NewServer(fixtures)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client.BaseURL = baseURL
+
+	return newGraphQLAdapter(client, NewRateLimiter()), fixtures
+}
+
+func TestGraphQLFetchPRsForRepo(t *testing.T) {
+	adapter, fixtures := newTestGraphQLAdapter(t)
+
+	startDate := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)
+
+	prs, err := adapter.FetchPRsForRepo("org", "api", startDate, endDate)
+	if err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	var got []string
+	for _, pr := range prs {
+		got = append(got, fmt.Sprintf("#%d %s->%s", pr.GetNumber(), pr.GetUser().GetLogin(), pr.GetBase().GetRef()))
+	}
+	if fmt.Sprint(got) != "[#11 alice->main #10 bob->develop]" {
+		t.Errorf("Unexpected PRs: %v", got)
+	}
+	if prs[0].GetBase().GetRepo().GetOwner().GetLogin() != "org" || prs[0].GetBase().GetRepo().GetName() != "api" {
+		t.Errorf("Expected base repo org/api, got %+v", prs[0].GetBase().GetRepo())
+	}
+
+	// A página 2 já contém PRs atualizados antes do início: a busca para sem pedir a página 3
+	if fmt.Sprint(fixtures.requests) != "[graphql:prs_page1 graphql:prs_page2]" {
+		t.Errorf("Unexpected requests: %v", fixtures.requests)
+	}
+}
+
+func TestGraphQLCommentsAndReactionsComeFromOneQueryPerPR(t *testing.T) {
+	adapter, fixtures := newTestGraphQLAdapter(t)
+	ctx := context.Background()
+
+	startDate := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)
+	if _, err := adapter.FetchPRsForRepo("org", "api", startDate, endDate); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	fetchRequests := len(fixtures.requests)
+
+	issueComments, err := adapter.ListPRComments(ctx, "org", "api", 11)
+	if err != nil || len(issueComments) != 1 || issueComments[0].GetUser().GetLogin() != "bob" {
+		t.Fatalf("Unexpected issue comments %v (err = %v)", issueComments, err)
+	}
+	reviewComments, err := adapter.ListPRReviewComments(ctx, "org", "api", 11)
+	if err != nil || len(reviewComments) != 1 || reviewComments[0].GetUser().GetLogin() != "ghost" {
+		t.Fatalf("Unexpected review comments %v (err = %v)", reviewComments, err)
+	}
+
+	reactions, err := adapter.ListIssueCommentReactions(ctx, "org", "api", issueComments[0].GetID())
+	if err != nil {
+		t.Fatalf("ListIssueCommentReactions() error = %v", err)
+	}
+	var contents []string
+	for _, reaction := range reactions {
+		contents = append(contents, reaction.GetContent()+":"+reaction.GetUser().GetLogin())
+	}
+	if fmt.Sprint(contents) != "[+1:carol heart:dave]" {
+		t.Errorf("Unexpected reactions: %v", contents)
+	}
+
+	if fmt.Sprint(fixtures.requests[fetchRequests:]) != "[graphql:pr_11_comments]" {
+		t.Errorf("Expected comments and reactions of PR #11 from a single query, got %v", fixtures.requests[fetchRequests:])
+	}
+}
+
+func TestGraphQLFallsBackToRESTForTruncatedReactions(t *testing.T) {
+	adapter, fixtures := newTestGraphQLAdapter(t)
+	ctx := context.Background()
+
+	startDate := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)
+	if _, err := adapter.FetchPRsForRepo("org", "api", startDate, endDate); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	if _, err := adapter.ListPRComments(ctx, "org", "api", 10); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+
+	// O comentário 1001 tem mais reações do que a consulta traz: a lista completa vem da API REST
+	reactions, err := adapter.ListIssueCommentReactions(ctx, "org", "api", 1001)
+	if err != nil {
+		t.Fatalf("ListIssueCommentReactions() error = %v", err)
+	}
+	if len(reactions) != 2 {
+		t.Errorf("Expected 2 reactions from REST, got %d", len(reactions))
+	}
+	if last := fixtures.requests[len(fixtures.requests)-1]; last != "rest:reactions_1001" {
+		t.Errorf("Expected REST fallback request, got %v", fixtures.requests)
+	}
+}
+
+func TestGraphQLFetchesSinglePROnDemand(t *testing.T) {
+	adapter, fixtures := newTestGraphQLAdapter(t)
+	ctx := context.Background()
+
+	// PR fora de qualquer busca anterior (ex: lista de PRs respondida pelo cache)
+	reviewComments, err := adapter.ListPRReviewComments(ctx, "org", "api", 7)
+	if err != nil {
+		t.Fatalf("ListPRReviewComments() error = %v", err)
+	}
+	if len(reviewComments) != 1 || reviewComments[0].GetID() != 701 {
+		t.Fatalf("Unexpected review comments: %v", reviewComments)
+	}
+
+	reactions, err := adapter.ListPullRequestCommentReactions(ctx, "org", "api", 701)
+	if err != nil || len(reactions) != 1 || reactions[0].GetContent() != "rocket" {
+		t.Errorf("Unexpected reactions %v (err = %v)", reactions, err)
+	}
+
+	issueComments, err := adapter.ListPRComments(ctx, "org", "api", 7)
+	if err != nil || len(issueComments) != 0 {
+		t.Errorf("Expected no issue comments, got %v (err = %v)", issueComments, err)
+	}
+
+	if fmt.Sprint(fixtures.requests) != "[graphql:pr_7_comments]" {
+		t.Errorf("Expected a single GraphQL query, got %v", fixtures.requests)
+	}
+}
+
+func TestGraphQLGetPR(t *testing.T) {
+	adapter, _ := newTestGraphQLAdapter(t)
+	ctx := context.Background()
+
+	pr, err := adapter.GetPR(ctx, "org", "api", 7)
+	if err != nil {
+		t.Fatalf("GetPR() error = %v", err)
+	}
+	if pr.GetMergedAt().IsZero() || pr.GetUser().GetLogin() != "dave" {
+		t.Errorf("Unexpected PR: %+v", pr)
+	}
+
+	// Um PR sem merge não tem data de merge para o cache: a busca falha em vez de retornar MergedAt nil
+	if _, err := adapter.GetPR(ctx, "org", "api", 13); err == nil || !strings.Contains(err.Error(), "não foi mergeado") {
+		t.Errorf("Expected an error for the unmerged PR #13, got %v", err)
+	}
+}
+
+func TestGraphQLEndpoint(t *testing.T) {
+	tests := []struct {
+		baseURL  string
+		expected string
+	}{
+		{"https://api.github.com/", "https://api.github.com/graphql"},
+		{"https://github.example.com/api/v3/", "https://github.example.com/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(tt.baseURL)
+		if got := graphqlEndpoint(client); got != tt.expected {
+			t.Errorf("graphqlEndpoint(%s) = %s, expected %s", tt.baseURL, got, tt.expected)
+		}
+	}
+}
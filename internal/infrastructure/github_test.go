@@ -0,0 +1,160 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// newTestGithubAdapter cria um githubAdapter apontando para um servidor de teste
+func newTestGithubAdapter(t *testing.T, handler http.Handler) *githubAdapter {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	baseURL, _ := url.Parse(server.URL + "/")
+	client.BaseURL = baseURL
+
+	return &githubAdapter{client: client, limiter: NewRateLimiter()}
+}
+
+// testPR monta o JSON mínimo de um PR para as respostas do servidor de teste
+func testPR(number int, updatedAt time.Time, mergedAt *time.Time) map[string]interface{} {
+	pr := map[string]interface{}{
+		"number":     number,
+		"updated_at": updatedAt.Format(time.RFC3339),
+		"user":       map[string]interface{}{"login": "alice"},
+		"base":       map[string]interface{}{"ref": "main"},
+	}
+	if mergedAt != nil {
+		pr["merged_at"] = mergedAt.Format(time.RFC3339)
+	}
+	return pr
+}
+
+func TestFetchPRsForRepoStopsAtPRsUpdatedBeforeStart(t *testing.T) {
+	startDate := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)
+	day := func(d int) *time.Time {
+		ts := time.Date(2026, 1, d, 12, 0, 0, 0, time.UTC)
+		return &ts
+	}
+
+	// Páginas ordenadas por updated desc; a página 3 já é toda anterior ao período e a 4 nunca deve ser pedida
+	pages := map[int][]map[string]interface{}{
+		1: {
+			testPR(10, *day(20), day(19)), // mergeado após o fim do período
+			testPR(9, *day(15), day(14)),
+			testPR(8, *day(14), nil), // fechado sem merge
+		},
+		2: {
+			testPR(7, *day(10), day(3)), // atualizado no período, mas mergeado antes
+			testPR(6, *day(8), day(8)),
+		},
+		3: {
+			testPR(5, *day(2), day(2)),
+			testPR(4, *day(1), day(1)),
+		},
+		4: {
+			testPR(3, *day(1), day(1)),
+		},
+	}
+
+	var requestedPages []int
+	adapter := newTestGithubAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sort") != "updated" || r.URL.Query().Get("direction") != "desc" {
+			t.Errorf("Expected sort=updated&direction=desc, got %s", r.URL.RawQuery)
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		requestedPages = append(requestedPages, page)
+
+		if _, ok := pages[page+1]; ok {
+			next := *r.URL
+			query := next.Query()
+			query.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.RequestURI()))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[page])
+	}))
+
+	prs, err := adapter.FetchPRsForRepo("org", "api", startDate, endDate)
+	if err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	var numbers []int
+	for _, pr := range prs {
+		numbers = append(numbers, pr.GetNumber())
+	}
+	if fmt.Sprint(numbers) != "[9 6]" {
+		t.Errorf("Expected PRs [9 6], got %v", numbers)
+	}
+	if fmt.Sprint(requestedPages) != "[1 2 3]" {
+		t.Errorf("Expected pagination to stop at page 3, requested %v", requestedPages)
+	}
+	if usage := adapter.APIUsage(); usage.Requests != 3 {
+		t.Errorf("Expected one request per page, got %d", usage.Requests)
+	}
+}
+
+func TestListOrgRepositoriesFollowsPagination(t *testing.T) {
+	pages := map[int][]map[string]interface{}{
+		1: {
+			{"name": "svc-billing", "owner": map[string]interface{}{"login": "org"}, "default_branch": "main", "topics": []string{"backend"}},
+			{"name": "legacy-archive", "owner": map[string]interface{}{"login": "org"}, "default_branch": "master", "archived": true},
+		},
+		2: {
+			{"name": "web", "owner": map[string]interface{}{"login": "org"}, "default_branch": "trunk"},
+		},
+	}
+
+	adapter := newTestGithubAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/org/repos" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page == 1 {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/orgs/org/repos?page=2>; rel="next"`, r.Host))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[page])
+	}))
+
+	repos, err := adapter.ListOrgRepositories(context.Background(), "org")
+	if err != nil {
+		t.Fatalf("ListOrgRepositories() error = %v", err)
+	}
+
+	var got []string
+	for _, repo := range repos {
+		got = append(got, fmt.Sprintf("%s/%s@%s archived=%v topics=%v", repo.Owner, repo.Name, repo.DefaultBranch, repo.Archived, repo.Topics))
+	}
+	expected := []string{
+		"org/svc-billing@main archived=false topics=[backend]",
+		"org/legacy-archive@master archived=true topics=[]",
+		"org/web@trunk archived=false topics=[]",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
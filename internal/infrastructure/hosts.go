@@ -0,0 +1,63 @@
+package infrastructure
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+// RepositoryHosts associa cada repositório ao host da instância GitHub de onde ele é buscado.
+// O cache local separa os dados por host, já que o mesmo owner/repo pode existir em instâncias diferentes
+type RepositoryHosts struct {
+	defaultHost string
+	routes      map[string]string // "owner/repo" -> host
+}
+
+// NewRepositoryHosts cria o mapeamento usando defaultHost (host ou URL) para repositórios sem rota
+func NewRepositoryHosts(defaultHost string) *RepositoryHosts {
+	return &RepositoryHosts{
+		defaultHost: NormalizeHost(defaultHost),
+		routes:      make(map[string]string),
+	}
+}
+
+// Route associa o repositório owner/repo ao host informado (host ou URL)
+func (h *RepositoryHosts) Route(owner, repo, host string) {
+	h.routes[routeKey(owner, repo)] = NormalizeHost(host)
+}
+
+// Host retorna o host do repositório; sem mapeamento, todos os repositórios são do github.com
+func (h *RepositoryHosts) Host(owner, repo string) string {
+	if h == nil {
+		return database.DefaultHost
+	}
+	if host, ok := h.routes[routeKey(owner, repo)]; ok {
+		return host
+	}
+	return h.defaultHost
+}
+
+// NormalizeHost reduz um host ou URL da API ao nome do host usado no cache: vazio, github.com e
+// api.github.com viram github.com, e URLs do GHES (ex: https://ghe.corp.com/api/v3/) viram ghe.corp.com
+func NormalizeHost(hostOrURL string) string {
+	host := strings.ToLower(strings.TrimSpace(hostOrURL))
+	if strings.Contains(host, "://") {
+		if parsed, err := url.Parse(host); err == nil {
+			host = parsed.Host
+		}
+	}
+	if slash := strings.Index(host, "/"); slash != -1 {
+		host = host[:slash]
+	}
+
+	if host == "" || host == "api.github.com" {
+		return database.DefaultHost
+	}
+	return host
+}
+
+// HostConfigurable é implementado pelos adaptadores com cache local, que separam os dados por host
+type HostConfigurable interface {
+	SetRepositoryHosts(hosts *RepositoryHosts)
+}
@@ -0,0 +1,39 @@
+package infrastructure
+
+import "testing"
+
+func TestNormalizeHost(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "github.com"},
+		{"github.com", "github.com"},
+		{"https://api.github.com/", "github.com"},
+		{"GHE.corp.com", "ghe.corp.com"},
+		{"https://ghe.corp.com", "ghe.corp.com"},
+		{"https://ghe.corp.com/api/v3/", "ghe.corp.com"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeHost(tt.input); got != tt.expected {
+			t.Errorf("NormalizeHost(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRepositoryHosts(t *testing.T) {
+	var unset *RepositoryHosts
+	if got := unset.Host("org", "api"); got != "github.com" {
+		t.Errorf("Expected github.com without hosts, got %q", got)
+	}
+
+	hosts := NewRepositoryHosts("https://ghe.corp.com")
+	hosts.Route("Org", "Web", "github.com")
+	if got := hosts.Host("org", "api"); got != "ghe.corp.com" {
+		t.Errorf("Expected the default host for org/api, got %q", got)
+	}
+	if got := hosts.Host("org", "web"); got != "github.com" {
+		t.Errorf("Expected github.com for org/web, got %q", got)
+	}
+}
@@ -0,0 +1,205 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+// ErrNotSynced indica que os dados pedidos nunca foram sincronizados para o banco local
+var ErrNotSynced = errors.New("dados não sincronizados no banco local")
+
+// ErrPartiallySynced indica que apenas parte do período pedido foi sincronizada; os dados
+// disponíveis são retornados junto com o erro, e o relatório deve ser marcado como incompleto
+var ErrPartiallySynced = errors.New("período sincronizado apenas parcialmente no banco local")
+
+// OfflineGithubAdapter implementa GithubAdapter respondendo apenas pelo banco de dados local,
+// sem token e sem acesso à API do GitHub
+type OfflineGithubAdapter struct {
+	db    database.CommentDatabase
+	hosts *RepositoryHosts // Host de cada repositório no banco (nil = github.com)
+}
+
+// NewOfflineGithubAdapter cria um adaptador offline sobre um banco já sincronizado. O banco é aberto
+// sem migrar: algumas migrações descartam dados em cache, que no modo offline não podem ser buscados de novo
+func NewOfflineGithubAdapter(dbPath string) (CacheableGithubAdapter, error) {
+	// Sem o banco não há o que consultar; evita criar um arquivo vazio
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("banco de dados %s não encontrado (execute uma vez sem --offline para sincronizar): %v", dbPath, err)
+	}
+
+	pending, err := database.PendingMigrations(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar versão do banco: %v", err)
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("banco de dados %s tem %d migração(ões) pendente(s) (versão %d em diante); execute 'pr-champion db migrate' ou uma sincronização sem --offline antes de usar o modo offline",
+			dbPath, len(pending), pending[0].Version)
+	}
+
+	db, err := database.OpenSQLiteDatabase(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao inicializar banco de dados: %v", err)
+	}
+
+	return NewOfflineGithubAdapterWithDatabase(db), nil
+}
+
+// NewOfflineGithubAdapterWithDatabase cria um adaptador offline sobre um CommentDatabase existente
+func NewOfflineGithubAdapterWithDatabase(db database.CommentDatabase) *OfflineGithubAdapter {
+	return &OfflineGithubAdapter{db: db}
+}
+
+// SetRepositoryHosts define o host de cada repositório, usado para ler os dados certos do banco
+func (o *OfflineGithubAdapter) SetRepositoryHosts(hosts *RepositoryHosts) {
+	o.hosts = hosts
+}
+
+// repoDB retorna o banco restrito ao host do repositório
+func (o *OfflineGithubAdapter) repoDB(owner, repo string) database.CommentDatabase {
+	return o.db.WithHost(o.hosts.Host(owner, repo))
+}
+
+// FetchPRsForRepo responde com os PRs mergeados já sincronizados para o repositório. Quando o fim
+// do período passa da última sincronização, retorna os PRs disponíveis e um erro ErrPartiallySynced
+func (o *OfflineGithubAdapter) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
+	until := endDate.Add(24 * time.Hour)
+	db := o.repoDB(owner, name)
+
+	sync, err := db.GetRepoSync(owner, name)
+	if err != nil {
+		return nil, err
+	}
+	if sync == nil {
+		return nil, fmt.Errorf("%w: o repositório %s/%s nunca foi sincronizado", ErrNotSynced, owner, name)
+	}
+	if startDate.Before(sync.SyncedFrom) || startDate.After(sync.SyncedUntil) {
+		return nil, fmt.Errorf("%w: %s/%s sincronizado apenas de %s até %s, período pedido começa em %s",
+			ErrNotSynced, owner, name,
+			sync.SyncedFrom.Format("2006-01-02 15:04"), sync.SyncedUntil.Format("2006-01-02 15:04"),
+			startDate.Format("2006-01-02 15:04"))
+	}
+
+	prs, err := mergedPRsFromDatabase(db, owner, name, startDate, until)
+	if err != nil {
+		return nil, err
+	}
+
+	// O fim do período pode passar da última sincronização (ex: --days até hoje): usa o que existe,
+	// mas informa o trecho descoberto para que o relatório não se declare completo
+	if until.After(sync.SyncedUntil) {
+		return prs, fmt.Errorf("%w: %s/%s sincronizado apenas até %s, PRs de %s até %s não estão no banco",
+			ErrPartiallySynced, owner, name,
+			sync.SyncedUntil.Format("2006-01-02 15:04"),
+			sync.SyncedUntil.Format("2006-01-02 15:04"), until.Format("2006-01-02 15:04"))
+	}
+
+	return prs, nil
+}
+
+// GetPR busca os dados de um PR salvo no banco
+func (o *OfflineGithubAdapter) GetPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	prData, err := o.repoDB(owner, repo).GetPR(owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if prData == nil || prData.Title == "" {
+		return nil, fmt.Errorf("%w: PR #%d em %s/%s", ErrNotSynced, prNumber, owner, repo)
+	}
+	return prData.ToGithubPR(), nil
+}
+
+// ListPRComments retorna os issue comments salvos de um PR
+func (o *OfflineGithubAdapter) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	if err := o.ensureCommentsChecked(owner, repo, prNumber, "issue"); err != nil {
+		return nil, err
+	}
+
+	cachedComments, err := o.repoDB(owner, repo).GetCommentsByPRAndType(owner, repo, prNumber, "issue")
+	if err != nil {
+		return nil, err
+	}
+	return convertCachedCommentsToGithub(cachedComments), nil
+}
+
+// ListPRReviewComments retorna os review comments salvos de um PR
+func (o *OfflineGithubAdapter) ListPRReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.PullRequestComment, error) {
+	if err := o.ensureCommentsChecked(owner, repo, prNumber, "review"); err != nil {
+		return nil, err
+	}
+
+	cachedComments, err := o.repoDB(owner, repo).GetCommentsByPRAndType(owner, repo, prNumber, "review")
+	if err != nil {
+		return nil, err
+	}
+	return convertCachedReviewCommentsToGithub(cachedComments), nil
+}
+
+// ListIssueCommentReactions retorna as reações salvas de um issue comment
+func (o *OfflineGithubAdapter) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return o.listReactions(owner, repo, commentID, "issue_comment")
+}
+
+// ListPullRequestCommentReactions retorna as reações salvas de um review comment
+func (o *OfflineGithubAdapter) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return o.listReactions(owner, repo, commentID, "review_comment")
+}
+
+// listReactions busca as reações de um comentário cujas reações já foram verificadas
+func (o *OfflineGithubAdapter) listReactions(owner, repo string, commentID int64, reactionType string) ([]*github.Reaction, error) {
+	db := o.repoDB(owner, repo)
+
+	comment, err := db.GetComment(owner, repo, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || !comment.ReactionsChecked {
+		return nil, fmt.Errorf("%w: reações do comentário %d em %s/%s", ErrNotSynced, commentID, owner, repo)
+	}
+
+	cachedReactions, err := db.GetReactionsByType(commentID, reactionType)
+	if err != nil {
+		return nil, err
+	}
+	return convertCachedReactionsToGithub(cachedReactions), nil
+}
+
+// ensureCommentsChecked verifica se os comentários do tipo informado já foram sincronizados para o PR
+func (o *OfflineGithubAdapter) ensureCommentsChecked(owner, repo string, prNumber int, commentType string) error {
+	prData, err := o.repoDB(owner, repo).GetPR(owner, repo, prNumber)
+	if err != nil {
+		return err
+	}
+
+	checked := false
+	if prData != nil {
+		switch commentType {
+		case "issue":
+			checked = prData.IssueCommentsChecked
+		case "review":
+			checked = prData.ReviewCommentsChecked
+		}
+	}
+	if !checked {
+		return fmt.Errorf("%w: comentários (%s) do PR #%d em %s/%s", ErrNotSynced, commentType, prNumber, owner, repo)
+	}
+	return nil
+}
+
+// ClearCache não é permitido no modo offline: o banco é a única fonte dos dados
+func (o *OfflineGithubAdapter) ClearCache() error {
+	return fmt.Errorf("não é possível limpar o cache no modo offline")
+}
+
+// Close fecha a conexão com o banco
+func (o *OfflineGithubAdapter) Close() error {
+	if o.db != nil {
+		return o.db.Close()
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+	"github.com/thrcorrea/PRPG/internal/database"
+)
+
+func TestOfflineAdapterServesSyncedData(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	client := &fakePRsClient{prs: []*github.PullRequest{mergedPR(1, day(3)), mergedPR(2, day(10))}}
+	cached := newTestCachedAdapter(t, client)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := cached.FetchPRsForRepo("org", "api", start, end); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+	// Sincroniza os comentários do PR #1 (sem comentários)
+	ctx := context.Background()
+	if _, err := cached.ListPRComments(ctx, "org", "api", 1); err != nil {
+		t.Fatalf("ListPRComments() error = %v", err)
+	}
+
+	offline := NewOfflineGithubAdapterWithDatabase(cached.db)
+
+	prs, err := offline.FetchPRsForRepo("org", "api", start.AddDate(0, 0, 5), end)
+	if err != nil {
+		t.Fatalf("offline FetchPRsForRepo() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].GetNumber() != 2 {
+		t.Errorf("Expected only PR #2 offline, got %v", prNumbers(prs))
+	}
+
+	comments, err := offline.ListPRComments(ctx, "org", "api", 1)
+	if err != nil || len(comments) != 0 {
+		t.Errorf("Expected synced empty comment list for PR #1, got %v (err = %v)", comments, err)
+	}
+
+	if len(client.calls) != 1 {
+		t.Errorf("Offline adapter must not call the API, got %d calls", len(client.calls))
+	}
+}
+
+func TestOfflineAdapterFailsForUnsyncedData(t *testing.T) {
+	client := &fakePRsClient{}
+	cached := newTestCachedAdapter(t, client)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := cached.FetchPRsForRepo("org", "api", start, end); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	offline := NewOfflineGithubAdapterWithDatabase(cached.db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"repo never synced", func() error {
+			_, err := offline.FetchPRsForRepo("org", "web", start, end)
+			return err
+		}},
+		{"range before sync", func() error {
+			_, err := offline.FetchPRsForRepo("org", "api", start.AddDate(0, -1, 0), end)
+			return err
+		}},
+		{"comments never synced", func() error {
+			_, err := offline.ListPRReviewComments(ctx, "org", "api", 1)
+			return err
+		}},
+		{"reactions never synced", func() error {
+			_, err := offline.ListIssueCommentReactions(ctx, "org", "api", 42)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrNotSynced) {
+				t.Errorf("Expected ErrNotSynced, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOfflineAdapterReportsPartiallySyncedRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	client := &fakePRsClient{prs: []*github.PullRequest{mergedPR(1, day(3))}}
+	cached := newTestCachedAdapter(t, client)
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	if _, err := cached.FetchPRsForRepo("org", "api", start, end); err != nil {
+		t.Fatalf("FetchPRsForRepo() error = %v", err)
+	}
+
+	offline := NewOfflineGithubAdapterWithDatabase(cached.db)
+
+	// O fim do período passa da sincronização: os PRs disponíveis voltam junto com o erro
+	prs, err := offline.FetchPRsForRepo("org", "api", start, end.AddDate(0, 0, 10))
+	if !errors.Is(err, ErrPartiallySynced) || errors.Is(err, ErrNotSynced) {
+		t.Errorf("Expected ErrPartiallySynced, got %v", err)
+	}
+	if len(prs) != 1 || prs[0].GetNumber() != 1 {
+		t.Errorf("Expected the synced PR #1, got %v", prNumbers(prs))
+	}
+}
+
+// fakeDefaultBranchClient adiciona a resolução da branch padrão ao cliente em memória
+type fakeDefaultBranchClient struct {
+	fakePRsClient
+	branch string
+}
+
+func (f *fakeDefaultBranchClient) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
+	return f.branch, nil
+}
+
+func TestOfflineAdapterServesResolvedDefaultBranch(t *testing.T) {
+	cached := newTestCachedAdapter(t, &fakeDefaultBranchClient{branch: "trunk"})
+	ctx := context.Background()
+
+	if _, err := cached.GetDefaultBranch(ctx, "org", "api"); err != nil {
+		t.Fatalf("GetDefaultBranch() error = %v", err)
+	}
+
+	offline := NewOfflineGithubAdapterWithDatabase(cached.db)
+	branch, err := offline.GetDefaultBranch(ctx, "org", "api")
+	if err != nil || branch != "trunk" {
+		t.Errorf("Expected trunk offline, got %q (err = %v)", branch, err)
+	}
+
+	if _, err := offline.GetDefaultBranch(ctx, "org", "web"); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("Expected ErrNotSynced for a branch never resolved, got %v", err)
+	}
+}
+
+func TestOfflineAdapterRefusesDatabaseWithPendingMigrations(t *testing.T) {
+	// Um arquivo vazio é um banco SQLite válido sem nenhuma migração aplicada
+	path := filepath.Join(t.TempDir(), "cache.db")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if _, err := NewOfflineGithubAdapter(path); err == nil || !strings.Contains(err.Error(), "db migrate") {
+		t.Fatalf("Expected an error asking to run db migrate, got %v", err)
+	}
+
+	// O modo offline não pode migrar o banco por conta própria
+	pending, err := database.PendingMigrations(path)
+	if err != nil || len(pending) == 0 {
+		t.Errorf("Expected the database to stay unmigrated, got %d pending (%v)", len(pending), err)
+	}
+
+	// Depois de migrado, o mesmo banco abre normalmente
+	if _, err := database.MigrateDatabase(path); err != nil {
+		t.Fatalf("MigrateDatabase() error = %v", err)
+	}
+	offline, err := NewOfflineGithubAdapter(path)
+	if err != nil {
+		t.Fatalf("NewOfflineGithubAdapter() error = %v", err)
+	}
+	offline.Close()
+}
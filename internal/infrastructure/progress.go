@@ -0,0 +1,27 @@
+package infrastructure
+
+import (
+	"io"
+	"os"
+)
+
+// ProgressConfigurable é implementado pelos adaptadores que escrevem mensagens de progresso,
+// permitindo separá-las da saída do relatório (ex: stderr quando o JSON vai para stdout)
+type ProgressConfigurable interface {
+	SetProgressOutput(w io.Writer)
+}
+
+// progressOutput retorna w ou, quando nil, a saída padrão
+func progressOutput(w io.Writer) io.Writer {
+	if w == nil {
+		return os.Stdout
+	}
+	return w
+}
+
+// setProgressOutput repassa a saída de progresso ao cliente, quando ele a aceita
+func setProgressOutput(client GithubAdapter, w io.Writer) {
+	if configurable, ok := client.(ProgressConfigurable); ok {
+		configurable.SetProgressOutput(w)
+	}
+}
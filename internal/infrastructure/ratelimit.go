@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// RateLimitStatus é o último estado conhecido do rate limit da API do GitHub
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Known     bool // false enquanto nenhuma resposta da API foi recebida
+}
+
+// RateLimitReporter é implementado pelos adaptadores que acompanham o rate limit da API
+type RateLimitReporter interface {
+	RateLimit() RateLimitStatus
+}
+
+// APIUsage conta as requisições feitas à API e o tempo gasto nelas
+type APIUsage struct {
+	Requests int
+	Time     time.Duration
+}
+
+// APIUsageReporter é implementado pelos adaptadores que contam as requisições feitas à API
+type APIUsageReporter interface {
+	APIUsage() APIUsage
+}
+
+// RateLimiter acompanha o rate limit informado pelas respostas da API e, quando ele se esgota,
+// aguarda até o reset antes de continuar. Limites secundários (abuse) são tratados com backoff exponencial.
+// Como toda requisição REST e GraphQL passa por ele, também conta as requisições feitas.
+// É seguro para uso concorrente.
+type RateLimiter struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+	usage  APIUsage
+
+	MaxRetries  int           // Número máximo de novas tentativas após um erro de rate limit
+	BaseBackoff time.Duration // Espera inicial do backoff exponencial para limites secundários
+	MaxBackoff  time.Duration // Espera máxima entre tentativas
+
+	sleep  func(ctx context.Context, d time.Duration) error
+	now    func() time.Time
+	output io.Writer // Saída das mensagens de espera (nil = saída padrão)
+}
+
+// NewRateLimiter cria um RateLimiter com os valores padrão
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		MaxRetries:  5,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+		sleep:       sleepContext,
+		now:         time.Now,
+	}
+}
+
+// RateLimit retorna o último estado conhecido do rate limit
+func (r *RateLimiter) RateLimit() RateLimitStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// SetProgressOutput define onde as mensagens de espera pelo rate limit são escritas
+func (r *RateLimiter) SetProgressOutput(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.output = w
+}
+
+// progress retorna a saída das mensagens de espera
+func (r *RateLimiter) progress() io.Writer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return progressOutput(r.output)
+}
+
+// APIUsage retorna as requisições feitas à API até o momento
+func (r *RateLimiter) APIUsage() APIUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usage
+}
+
+// Do executa uma chamada à API respeitando o rate limit. A chamada é repetida após a espera
+// quando a API responde com erro de rate limit primário ou secundário.
+func (r *RateLimiter) Do(ctx context.Context, call func() (*github.Response, error)) error {
+	backoff := r.BaseBackoff
+
+	for attempt := 0; ; attempt++ {
+		if err := r.waitIfExhausted(ctx); err != nil {
+			return err
+		}
+
+		started := r.now()
+		resp, err := call()
+		r.countRequest(r.now().Sub(started))
+		if resp != nil {
+			r.update(resp.Rate)
+		}
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= r.MaxRetries {
+			return err
+		}
+
+		var rateErr *github.RateLimitError
+		var abuseErr *github.AbuseRateLimitError
+		switch {
+		case errors.As(err, &rateErr):
+			r.update(rateErr.Rate)
+			wait := r.untilReset(rateErr.Rate.Reset.Time)
+			fmt.Fprintf(r.progress(), "    ⏳ Rate limit da API esgotado; aguardando %s até o reset (%s)\n",
+				wait.Round(time.Second), rateErr.Rate.Reset.Time.Local().Format("15:04:05"))
+			if err := r.sleep(ctx, wait); err != nil {
+				return err
+			}
+		case errors.As(err, &abuseErr):
+			wait := backoff
+			if abuseErr.RetryAfter != nil && *abuseErr.RetryAfter > 0 {
+				wait = *abuseErr.RetryAfter
+			}
+			fmt.Fprintf(r.progress(), "    ⏳ Limite secundário da API atingido; nova tentativa em %s (%d/%d)\n",
+				wait.Round(time.Second), attempt+1, r.MaxRetries)
+			if err := r.sleep(ctx, wait); err != nil {
+				return err
+			}
+			backoff *= 2
+			if backoff > r.MaxBackoff {
+				backoff = r.MaxBackoff
+			}
+		default:
+			return err
+		}
+	}
+}
+
+// countRequest registra uma requisição feita à API
+func (r *RateLimiter) countRequest(elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.Requests++
+	r.usage.Time += elapsed
+}
+
+// update registra o estado do rate limit informado por uma resposta
+func (r *RateLimiter) update(rate github.Rate) {
+	if rate.Limit == 0 && rate.Reset.Time.IsZero() {
+		return // Resposta sem cabeçalhos de rate limit
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = RateLimitStatus{
+		Limit:     rate.Limit,
+		Remaining: rate.Remaining,
+		Reset:     rate.Reset.Time,
+		Known:     true,
+	}
+}
+
+// waitIfExhausted aguarda o reset quando o último estado conhecido indica que não restam chamadas
+func (r *RateLimiter) waitIfExhausted(ctx context.Context) error {
+	status := r.RateLimit()
+	if !status.Known || status.Remaining > 0 {
+		return nil
+	}
+
+	wait := r.untilReset(status.Reset)
+	if wait <= 0 {
+		return nil
+	}
+
+	fmt.Fprintf(r.progress(), "    ⏳ Rate limit da API esgotado; aguardando %s até o reset (%s)\n",
+		wait.Round(time.Second), status.Reset.Local().Format("15:04:05"))
+	if err := r.sleep(ctx, wait); err != nil {
+		return err
+	}
+
+	// Após o reset, assume o limite cheio até que a próxima resposta informe o valor real
+	r.mu.Lock()
+	if r.status.Reset.Equal(status.Reset) {
+		r.status.Remaining = r.status.Limit
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+// untilReset calcula quanto falta para o reset, com uma pequena margem de segurança
+func (r *RateLimiter) untilReset(reset time.Time) time.Duration {
+	wait := reset.Sub(r.now()) + time.Second
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// sleepContext aguarda a duração informada ou o cancelamento do contexto
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
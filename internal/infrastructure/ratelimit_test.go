@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// newTestRateLimiter cria um RateLimiter com relógio fixo que registra as esperas em vez de dormir
+func newTestRateLimiter(now time.Time) (*RateLimiter, *[]time.Duration) {
+	var sleeps []time.Duration
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return limiter, &sleeps
+}
+
+func responseWithRate(limit, remaining int, reset time.Time) *github.Response {
+	return &github.Response{
+		Response: &http.Response{StatusCode: http.StatusOK},
+		Rate:     github.Rate{Limit: limit, Remaining: remaining, Reset: github.Timestamp{Time: reset}},
+	}
+}
+
+func TestRateLimiterTracksResponses(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	limiter, sleeps := newTestRateLimiter(now)
+
+	err := limiter.Do(context.Background(), func() (*github.Response, error) {
+		return responseWithRate(5000, 4999, now.Add(time.Hour)), nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	status := limiter.RateLimit()
+	if !status.Known || status.Limit != 5000 || status.Remaining != 4999 {
+		t.Errorf("Unexpected rate limit status: %+v", status)
+	}
+	if len(*sleeps) != 0 {
+		t.Errorf("Expected no waits, got %v", *sleeps)
+	}
+}
+
+func TestRateLimiterWaitsUntilResetWhenExhausted(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	limiter, sleeps := newTestRateLimiter(now)
+	reset := now.Add(10 * time.Minute)
+
+	calls := 0
+	err := limiter.Do(context.Background(), func() (*github.Response, error) {
+		calls++
+		if calls == 1 {
+			rate := github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: reset}}
+			return responseWithRate(5000, 0, reset), &github.RateLimitError{Rate: rate, Message: "API rate limit exceeded"}
+		}
+		return responseWithRate(5000, 4999, reset.Add(time.Hour)), nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected the call to be retried once, got %d calls", calls)
+	}
+	if len(*sleeps) == 0 || (*sleeps)[0] < 10*time.Minute {
+		t.Errorf("Expected a wait until reset, got %v", *sleeps)
+	}
+}
+
+func TestRateLimiterBacksOffOnSecondaryLimit(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	limiter, sleeps := newTestRateLimiter(now)
+
+	calls := 0
+	err := limiter.Do(context.Background(), func() (*github.Response, error) {
+		calls++
+		if calls <= 3 {
+			return nil, &github.AbuseRateLimitError{Message: "secondary rate limit"}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// Cada nova tentativa é uma requisição à API
+	if usage := limiter.APIUsage(); usage.Requests != 4 {
+		t.Errorf("Expected 4 requests, got %d", usage.Requests)
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	if len(*sleeps) != len(expected) {
+		t.Fatalf("Expected %d waits, got %v", len(expected), *sleeps)
+	}
+	for i, wait := range expected {
+		if (*sleeps)[i] != wait {
+			t.Errorf("Wait %d = %s, want %s", i, (*sleeps)[i], wait)
+		}
+	}
+}
+
+func TestRateLimiterGivesUpAfterMaxRetries(t *testing.T) {
+	limiter, _ := newTestRateLimiter(time.Now())
+	limiter.MaxRetries = 2
+
+	calls := 0
+	err := limiter.Do(context.Background(), func() (*github.Response, error) {
+		calls++
+		return nil, &github.AbuseRateLimitError{Message: "secondary rate limit"}
+	})
+
+	var abuseErr *github.AbuseRateLimitError
+	if !errors.As(err, &abuseErr) {
+		t.Fatalf("Expected AbuseRateLimitError after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls (1 + 2 retries), got %d", calls)
+	}
+
+	// Erros que não são de rate limit não são repetidos
+	calls = 0
+	_ = limiter.Do(context.Background(), func() (*github.Response, error) {
+		calls++
+		return nil, errors.New("not found")
+	})
+	if calls != 1 {
+		t.Errorf("Expected non rate-limit errors not to be retried, got %d calls", calls)
+	}
+}
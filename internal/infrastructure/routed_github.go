@@ -0,0 +1,116 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// RoutedGithubAdapter encaminha cada chamada ao cliente do host do repositório,
+// permitindo misturar github.com e instâncias GitHub Enterprise Server na mesma execução
+type RoutedGithubAdapter struct {
+	defaultClient GithubAdapter
+	routes        map[string]GithubAdapter // "owner/repo" -> cliente do host
+}
+
+// NewRoutedGithubAdapter cria um roteador que usa defaultClient para repositórios sem rota
+func NewRoutedGithubAdapter(defaultClient GithubAdapter) *RoutedGithubAdapter {
+	return &RoutedGithubAdapter{
+		defaultClient: defaultClient,
+		routes:        make(map[string]GithubAdapter),
+	}
+}
+
+// Route faz as chamadas do repositório owner/repo usarem o cliente informado
+func (r *RoutedGithubAdapter) Route(owner, repo string, client GithubAdapter) {
+	r.routes[routeKey(owner, repo)] = client
+}
+
+func routeKey(owner, repo string) string {
+	return strings.ToLower(owner + "/" + repo)
+}
+
+// clientFor retorna o cliente responsável pelo repositório
+func (r *RoutedGithubAdapter) clientFor(owner, repo string) GithubAdapter {
+	if client, ok := r.routes[routeKey(owner, repo)]; ok {
+		return client
+	}
+	return r.defaultClient
+}
+
+func (r *RoutedGithubAdapter) FetchPRsForRepo(owner, name string, startDate, endDate time.Time) ([]*github.PullRequest, error) {
+	return r.clientFor(owner, name).FetchPRsForRepo(owner, name, startDate, endDate)
+}
+
+func (r *RoutedGithubAdapter) GetPR(ctx context.Context, owner, repo string, prNumber int) (*github.PullRequest, error) {
+	return r.clientFor(owner, repo).GetPR(ctx, owner, repo, prNumber)
+}
+
+func (r *RoutedGithubAdapter) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return r.clientFor(owner, repo).ListIssueCommentReactions(ctx, owner, repo, commentID)
+}
+
+func (r *RoutedGithubAdapter) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return r.clientFor(owner, repo).ListPullRequestCommentReactions(ctx, owner, repo, commentID)
+}
+
+func (r *RoutedGithubAdapter) ListPRComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.IssueComment, error) {
+	return r.clientFor(owner, repo).ListPRComments(ctx, owner, repo, prNumber)
+}
+
+func (r *RoutedGithubAdapter) ListPRReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]*github.PullRequestComment, error) {
+	return r.clientFor(owner, repo).ListPRReviewComments(ctx, owner, repo, prNumber)
+}
+
+// clients retorna o cliente padrão e os clientes das rotas, cada cliente compartilhado uma única vez
+func (r *RoutedGithubAdapter) clients() []GithubAdapter {
+	clients := []GithubAdapter{r.defaultClient}
+	seen := map[GithubAdapter]bool{r.defaultClient: true}
+	for _, client := range r.routes {
+		if !seen[client] {
+			seen[client] = true
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// RateLimit retorna o menor rate limit restante entre os clientes de todos os hosts, que é o que
+// limita a execução; são os mesmos clientes somados em APIUsage
+func (r *RoutedGithubAdapter) RateLimit() RateLimitStatus {
+	var lowest RateLimitStatus
+	for _, client := range r.clients() {
+		reporter, ok := client.(RateLimitReporter)
+		if !ok {
+			continue
+		}
+		status := reporter.RateLimit()
+		if status.Known && (!lowest.Known || status.Remaining < lowest.Remaining) {
+			lowest = status
+		}
+	}
+	return lowest
+}
+
+// SetProgressOutput repassa a saída de progresso a todos os clientes
+func (r *RoutedGithubAdapter) SetProgressOutput(w io.Writer) {
+	for _, client := range r.clients() {
+		setProgressOutput(client, w)
+	}
+}
+
+// APIUsage soma as requisições feitas por todos os clientes, contando uma vez cada cliente compartilhado
+func (r *RoutedGithubAdapter) APIUsage() APIUsage {
+	var total APIUsage
+	for _, client := range r.clients() {
+		if reporter, ok := client.(APIUsageReporter); ok {
+			usage := reporter.APIUsage()
+			total.Requests += usage.Requests
+			total.Time += usage.Time
+		}
+	}
+	return total
+}
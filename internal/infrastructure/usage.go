@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+)
+
+// Recursos contabilizados nas estatísticas de uso do cache
+const (
+	ResourcePRs            = "prs"
+	ResourceIssueComments  = "issue_comments"
+	ResourceReviewComments = "review_comments"
+	ResourceReactions      = "reactions"
+)
+
+// UsageResources lista os recursos na ordem em que aparecem nos relatórios
+var UsageResources = []string{ResourcePRs, ResourceIssueComments, ResourceReviewComments, ResourceReactions}
+
+// CacheCounter conta as consultas respondidas pelo banco (hits) e as que precisaram da API (misses)
+type CacheCounter struct {
+	Hits   int
+	Misses int
+}
+
+// HitRate retorna a fração das consultas respondidas pelo cache (0 quando não houve consultas)
+func (c CacheCounter) HitRate() float64 {
+	total := c.Hits + c.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(c.Hits) / float64(total)
+}
+
+// UsageStats resume o uso do cache e da API em uma execução
+type UsageStats struct {
+	Cache     map[string]CacheCounter // Hits e misses por recurso (ResourcePRs, ResourceReactions...)
+	APICalls  int                     // Requisições feitas à API (REST e GraphQL, incluindo cada página e nova tentativa)
+	APITime   time.Duration           // Tempo total gasto nessas requisições
+	RateLimit RateLimitStatus         // Último rate limit conhecido
+}
+
+// UsageReporter é implementado pelos adaptadores que contabilizam o uso do cache e da API
+type UsageReporter interface {
+	Usage() UsageStats
+}
+
+// usageCounters acumula as estatísticas de uso; é seguro para uso concorrente pelos workers
+type usageCounters struct {
+	mu    sync.Mutex
+	cache map[string]CacheCounter
+}
+
+// hit registra uma consulta respondida pelo banco
+func (u *usageCounters) hit(resource string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.cache == nil {
+		u.cache = make(map[string]CacheCounter)
+	}
+	counter := u.cache[resource]
+	counter.Hits++
+	u.cache[resource] = counter
+}
+
+// miss registra uma consulta que precisou da API
+func (u *usageCounters) miss(resource string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.cache == nil {
+		u.cache = make(map[string]CacheCounter)
+	}
+	counter := u.cache[resource]
+	counter.Misses++
+	u.cache[resource] = counter
+}
+
+// snapshot retorna uma cópia das estatísticas acumuladas
+func (u *usageCounters) snapshot() UsageStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stats := UsageStats{Cache: make(map[string]CacheCounter, len(u.cache))}
+	for resource, counter := range u.cache {
+		stats.Cache[resource] = counter
+	}
+	return stats
+}
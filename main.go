@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"sort"
@@ -17,6 +19,7 @@ import (
 
 // Repository representa um repositório para análise
 type Repository struct {
+	Host               string // Host do GitHub Enterprise Server (ex: ghe.corp.com); vazio = host padrão
 	Owner              string
 	Name               string
 	ProductionBranches []string // Lista de branches de produção aceitas (ex: [main, master, production])
@@ -37,19 +40,30 @@ type UserStats struct {
 	WeightedCommentWeeklyScore int            // Pontuação semanal por qualidade de comentários
 }
 
-// WeeklyData representa os dados de uma semana específica
-type WeeklyData struct {
-	StartDate             time.Time
-	EndDate               time.Time
-	UserPRs               map[string]int
-	Winner                string
-	RepoData              map[string]map[string]int // repo -> user -> PRs
-	UserComments          map[string]int            // comentários por usuário na semana
-	CommentWinner         string                    // vencedor da semana por comentários
-	UserWeightedComments  map[string]float64        // pontuação ponderada por usuário na semana
-	WeightedCommentWinner string                    // vencedor da semana por pontuação ponderada
+// PeriodData representa os dados de um período de apuração (semana, dia, sprint, mês ou trimestre)
+type PeriodData struct {
+	StartDate              time.Time
+	EndDate                time.Time
+	UserPRs                map[string]int
+	Winner                 string
+	RepoData               map[string]map[string]int     // repo -> user -> PRs
+	UserComments           map[string]int                // comentários por usuário na semana
+	CommentWinner          string                        // vencedor da semana por comentários
+	UserWeightedComments   map[string]float64            // pontuação ponderada por usuário na semana
+	WeightedCommentWinner  string                        // vencedor da semana por pontuação ponderada
+	RepoComments           map[string]map[string]int     // repo -> user -> comentários
+	RepoWeightedComments   map[string]map[string]float64 // repo -> user -> pontuação ponderada
+	RepoWinners            map[string][]string           // repo -> vencedores da semana por PRs no repositório
+	FirstMergeAt           map[string]time.Time          // primeiro merge de cada usuário na semana (critério de desempate)
+	Winners                []string                      // campeões por PRs (mais de um quando o título é dividido)
+	CommentWinners         []string                      // campeões por comentários
+	WeightedCommentWinners []string                      // campeões por qualidade de comentários
+	TiePolicy              string                        // política de desempate aplicada
 }
 
+// WeeklyData é o nome histórico de PeriodData, mantido por compatibilidade
+type WeeklyData = PeriodData
+
 // PRChampion é a estrutura principal da aplicação
 type PRChampion struct {
 	client       infrastructure.GithubAdapter
@@ -59,25 +73,96 @@ type PRChampion struct {
 	endDate      time.Time
 	weeklyData   []WeeklyData
 	userStats    map[string]*UserStats
+	scoring      *ScoringConfig                  // Pesos de pontuação (nil = padrão)
+	scorers      []Scorer                        // Rankings exibidos no relatório (nil = padrão)
+	scorerTotals map[string]map[string]float64   // scorer -> username -> pontuação acumulada
+	tiePolicy    TiePolicy                       // Política de desempate dos títulos semanais
+	calendar     *WeekCalendar                   // Fuso horário e início da semana usados no agrupamento
+	concurrency  int                             // Número de PRs processados em paralelo na busca de comentários
+	fetchErrors  []string                        // Dados que não puderam ser buscados (relatório incompleto)
+	freshness    *infrastructure.FreshnessPolicy // Validade do cache de comentários e reações (nil = padrão)
+	fetchTime    time.Duration                   // Tempo gasto na busca de PRs, comentários e reações
+	progressOut  io.Writer                       // Saída das mensagens de progresso (nil = saída padrão)
+	defaultHost  string                          // Host dos repositórios sem host (vazio = github.com)
+	dbPath       string                          // Banco SQLite usado como cache ou, no modo offline, como fonte
+	offline      bool                            // Relatório gerado apenas com os dados do banco local
 }
 
+// defaultDatabasePath é o banco SQLite local usado como cache (e como fonte no modo offline)
+const defaultDatabasePath = "./data/comments.db"
+
 // NewPRChampion cria uma nova instância do PR Champion
 func NewPRChampion(token string, repositories []Repository, startDate, endDate time.Time) (*PRChampion, error) {
+	return NewPRChampionWithOptions(infrastructure.ClientOptions{Token: token}, defaultDatabasePath, repositories, startDate, endDate)
+}
+
+// NewPRChampionWithOptions cria uma instância com a API, o token e a URL do GitHub informados, usando
+// dbPath como cache; repositórios qualificados com host usam um cliente próprio para o host
+func NewPRChampionWithOptions(opts infrastructure.ClientOptions, dbPath string, repositories []Repository, startDate, endDate time.Time) (*PRChampion, error) {
+	if err := checkRepositoryHosts(repositories, opts.BaseURL); err != nil {
+		return nil, err
+	}
+
+	githubClient, err := newGithubClientForRepositories(opts, repositories)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cria cliente com cache em banco de dados
-	cachedClient, err := infrastructure.NewCachedGithubAdapter(token, "./data/comments.db")
+	cachedClient, err := infrastructure.NewCachedGithubAdapterWithClient(githubClient, dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente com cache: %v", err)
 	}
 
+	pc := NewPRChampionWithClient(cachedClient, repositories, startDate, endDate)
+	pc.SetDefaultHost(opts.BaseURL)
+	pc.dbPath = dbPath
+	return pc, nil
+}
+
+// NewOfflinePRChampion cria uma instância que lê apenas o banco dbPath, sem acessar a API do GitHub.
+// githubURL identifica o host dos repositórios sem host, como na execução que sincronizou o banco
+func NewOfflinePRChampion(dbPath, githubURL string, repositories []Repository, startDate, endDate time.Time) (*PRChampion, error) {
+	if err := checkRepositoryHosts(repositories, githubURL); err != nil {
+		return nil, err
+	}
+
+	offlineClient, err := infrastructure.NewOfflineGithubAdapter(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente offline: %v", err)
+	}
+
+	pc := NewPRChampionWithClient(offlineClient, repositories, startDate, endDate)
+	pc.SetDefaultHost(githubURL)
+	pc.dbPath = dbPath
+	pc.offline = true
+	return pc, nil
+}
+
+// NewPRChampionWithClient cria uma instância do PR Champion sobre o cliente informado
+func NewPRChampionWithClient(client infrastructure.CacheableGithubAdapter, repositories []Repository, startDate, endDate time.Time) *PRChampion {
 	return &PRChampion{
-		client:       cachedClient,
-		cachedClient: cachedClient,
+		client:       client,
+		cachedClient: client,
 		repositories: repositories,
 		startDate:    startDate,
 		endDate:      endDate,
 		weeklyData:   []WeeklyData{},
 		userStats:    make(map[string]*UserStats),
-	}, nil
+	}
+}
+
+// SetScoringConfig define a configuração de pontuação usada nos cálculos
+func (pc *PRChampion) SetScoringConfig(cfg *ScoringConfig) {
+	pc.scoring = cfg
+}
+
+// scoringConfig retorna a configuração de pontuação ativa
+func (pc *PRChampion) scoringConfig() *ScoringConfig {
+	if pc.scoring == nil {
+		return DefaultScoringConfig()
+	}
+	return pc.scoring
 }
 
 // ClearCache limpa todo o cache do banco de dados
@@ -90,7 +175,10 @@ func (pc *PRChampion) ClearCache() error {
 
 // FetchMergedPRs busca todos os PRs mergeados no período especificado para todos os repositórios
 func (pc *PRChampion) FetchMergedPRs() error {
-	fmt.Printf("🔍 Buscando PRs mergeados de %s para %d repositórios...\n",
+	started := time.Now()
+	defer func() { pc.fetchTime = time.Since(started) }()
+
+	fmt.Fprintf(pc.progress(), "🔍 Buscando PRs mergeados de %s para %d repositórios...\n",
 		pc.startDate.Format("2006-01-02"), len(pc.repositories))
 
 	var allPRs []*github.PullRequest
@@ -101,11 +189,30 @@ func (pc *PRChampion) FetchMergedPRs() error {
 			productionBranches = []string{"main"} // Branch padrão se não especificada
 		}
 
-		fmt.Printf("  📁 Analisando %s/%s (branches: %s)...\n", repo.Owner, repo.Name, strings.Join(productionBranches, ", "))
+		fmt.Fprintf(pc.progress(), "  📁 Analisando %s/%s (branches: %s)...\n", repo.Owner, repo.Name, strings.Join(productionBranches, ", "))
 
-		repoPRs, err := pc.client.FetchPRsForRepo(repo.Owner, repo.Name, pc.startDate, pc.endDate)
+		matcher, err := pc.branchMatcher(repo, productionBranches)
+		if errors.Is(err, infrastructure.ErrNotSynced) {
+			return fmt.Errorf("modo offline: %v", err)
+		}
 		if err != nil {
-			fmt.Printf("  ⚠️  Erro ao buscar PRs do repo %s/%s: %v\n", repo.Owner, repo.Name, err)
+			fmt.Fprintf(pc.progress(), "  ⚠️  Erro ao resolver branches do repo %s/%s: %v\n", repo.Owner, repo.Name, err)
+			pc.recordFetchError(fmt.Errorf("erro ao resolver branches do repo %s/%s: %v", repo.Owner, repo.Name, err))
+			continue
+		}
+
+		repoPRs, err := pc.client.FetchPRsForRepo(repo.Owner, repo.Name, pc.startDate, pc.endDate)
+		if errors.Is(err, infrastructure.ErrNotSynced) {
+			// No modo offline não há como completar os dados: um relatório parcial seria enganoso
+			return fmt.Errorf("modo offline: %v", err)
+		}
+		if errors.Is(err, infrastructure.ErrPartiallySynced) {
+			// Usa os PRs disponíveis, mas o relatório passa a ser incompleto
+			fmt.Fprintf(pc.progress(), "    ⚠️  Modo offline: %v\n", err)
+			pc.recordFetchError(fmt.Errorf("modo offline: %v", err))
+		} else if err != nil {
+			fmt.Fprintf(pc.progress(), "  ⚠️  Erro ao buscar PRs do repo %s/%s: %v\n", repo.Owner, repo.Name, err)
+			pc.recordFetchError(fmt.Errorf("erro ao buscar PRs do repo %s/%s: %v", repo.Owner, repo.Name, err))
 			continue // Continua com os outros repositórios
 		}
 
@@ -114,37 +221,29 @@ func (pc *PRChampion) FetchMergedPRs() error {
 		for _, pr := range repoPRs {
 			if pr.Base != nil && pr.Base.Ref != nil {
 				prBaseBranch := *pr.Base.Ref
-				isProductionBranch := false
 
-				for _, branch := range productionBranches {
-					if prBaseBranch == branch {
-						isProductionBranch = true
-						break
-					}
-				}
-
-				if isProductionBranch {
+				if matcher.Match(prBaseBranch) {
 					productionPRs = append(productionPRs, pr)
 				} else {
-					fmt.Printf("    ❌ PR #%d ignorado (branch: %s, aceitas: %s)\n",
+					fmt.Fprintf(pc.progress(), "    ❌ PR #%d ignorado (branch: %s, aceitas: %s)\n",
 						pr.GetNumber(), prBaseBranch, strings.Join(productionBranches, ", "))
 				}
 			}
 		}
 
-		fmt.Printf("    ✅ %d PRs encontrados para branches de produção [%s] (total: %d)\n",
+		fmt.Fprintf(pc.progress(), "    ✅ %d PRs encontrados para branches de produção [%s] (total: %d)\n",
 			len(productionPRs), strings.Join(productionBranches, ", "), len(repoPRs))
 
 		allPRs = append(allPRs, productionPRs...)
 	}
 
-	fmt.Printf("📊 Encontrados %d PRs mergeados no período total\n", len(allPRs))
+	fmt.Fprintf(pc.progress(), "📊 Encontrados %d PRs mergeados no período total\n", len(allPRs))
 
 	pc.processWeeklyData(allPRs)
 
 	// Busca comentários para todos os PRs
 	if err := pc.fetchCommentsForPRs(allPRs); err != nil {
-		fmt.Printf("⚠️  Erro ao buscar comentários: %v\n", err)
+		fmt.Fprintf(pc.progress(), "⚠️  Erro ao buscar comentários: %v\n", err)
 	}
 	pc.calculateUserStats()
 
@@ -153,154 +252,193 @@ func (pc *PRChampion) FetchMergedPRs() error {
 
 // fetchCommentsForPRs busca comentários de todos os PRs
 func (pc *PRChampion) fetchCommentsForPRs(prs []*github.PullRequest) error {
-	fmt.Printf("💬 Buscando comentários dos PRs...\n")
+	fmt.Fprintf(pc.progress(), "💬 Buscando comentários dos PRs (%d em paralelo)...\n", pc.activeConcurrency())
 
 	ctx := context.Background()
+	calendar := pc.weekCalendar()
 	totalComments := 0
 
 	// Mapas para rastrear comentários por semana
-	weeklyComments := make(map[string]map[string]int)             // weekKey -> username -> count
-	weeklyWeightedComments := make(map[string]map[string]float64) // weekKey -> username -> weighted score
+	weeklyComments := make(map[string]map[string]int)                            // weekKey -> username -> count
+	weeklyWeightedComments := make(map[string]map[string]float64)                // weekKey -> username -> weighted score
+	weeklyRepoComments := make(map[string]map[string]map[string]int)             // weekKey -> repo -> username -> count
+	weeklyRepoWeightedComments := make(map[string]map[string]map[string]float64) // weekKey -> repo -> username -> weighted score
 	weekStarts := make(map[string]time.Time)
 
-	for _, pr := range prs {
-		repoOwner := pr.Base.Repo.Owner.GetLogin()
-		repoName := pr.Base.Repo.GetName()
-		prNumber := pr.GetNumber()
-		comments, err := pc.client.ListPRComments(ctx, repoOwner, repoName, prNumber)
-		if err != nil {
-			fmt.Printf("  ⚠️  Erro ao buscar comentários do PR #%d em %s/%s: %v\n", prNumber, repoOwner, repoName, err)
-			break
-		}
-
-		for _, comment := range comments {
-			commentTime := comment.CreatedAt.Time
-			username := comment.User.GetLogin()
-
-			// Filtra usuários excluídos (bots, sonarqube, etc.)
-			if isExcludedUser(username) {
-				continue
-			}
-
-			if username == pr.User.GetLogin() {
-				fmt.Println("    ❗ Comentário do autor do PR ignorado:", username)
-				continue // Pula comentários feitos pelo autor do PR
-			}
+	// Busca comentários e reações em paralelo; cada resultado fica na posição do seu PR
+	results := make([]prCommentsResult, len(prs))
+	progress := newProgressReporter(pc.progress(), "💬 Comentários", len(prs), pc.rateLimitStatus)
+	runWorkerPool(len(prs), pc.activeConcurrency(), func(i int) {
+		results[i] = pc.collectPRComments(ctx, prs[i])
+		progress.Done()
+	})
 
-			// Verifica se o comentário foi feito após o merge do PR
-			if pr.MergedAt != nil && commentTime.After(pr.MergedAt.Time) {
-				fmt.Printf("    ❗ Comentário pós-merge ignorado: %s (comentário: %s, merge: %s)\n",
-					username, commentTime.Format("02/01/2006 15:04"), pr.MergedAt.Time.Format("02/01/2006 15:04"))
-				continue
-			}
+	// Agrega sequencialmente, na ordem dos PRs, para manter o resultado determinístico
+	for i, pr := range prs {
+		result := results[i]
 
+		for _, comment := range result.comments {
 			// Determina a semana do comentário
-			weekStart := getWeekStart(pr.MergedAt.Time)
+			weekStart := calendar.PeriodStartOf(pr.MergedAt.Time)
 			weekKey := weekStart.Format("2006-01-02")
 
 			if weeklyComments[weekKey] == nil {
 				weeklyComments[weekKey] = make(map[string]int)
 				weeklyWeightedComments[weekKey] = make(map[string]float64)
+				weeklyRepoComments[weekKey] = make(map[string]map[string]int)
+				weeklyRepoWeightedComments[weekKey] = make(map[string]map[string]float64)
 				weekStarts[weekKey] = weekStart
 			}
+			if weeklyRepoComments[weekKey][comment.Repo] == nil {
+				weeklyRepoComments[weekKey][comment.Repo] = make(map[string]int)
+				weeklyRepoWeightedComments[weekKey][comment.Repo] = make(map[string]float64)
+			}
 
-			// Calcula pontuação ponderada baseada nas reações
-			commentScore := pc.calculateCommentScore(ctx, repoOwner, repoName, comment.GetID(), pr.MergedAt.Time)
-
-			weeklyComments[weekKey][username]++
-			weeklyWeightedComments[weekKey][username] += commentScore
+			weeklyComments[weekKey][comment.Username]++
+			weeklyWeightedComments[weekKey][comment.Username] += comment.WeightedScore
+			weeklyRepoComments[weekKey][comment.Repo][comment.Username]++
+			weeklyRepoWeightedComments[weekKey][comment.Repo][comment.Username] += comment.WeightedScore
 			totalComments++
+
+			pc.addScorerComment(comment)
 		}
 
-		reviewComments, err := pc.client.ListPRReviewComments(ctx, repoOwner, repoName, prNumber)
-		if err != nil {
-			fmt.Printf("  ⚠️  Erro ao buscar review comments do PR #%d em %s/%s: %v\n", prNumber, repoOwner, repoName, err)
-			break
+		// Falhas não interrompem a análise, mas marcam o relatório como incompleto
+		for _, err := range result.errs {
+			fmt.Fprintf(pc.progress(), "  ⚠️  %v\n", err)
+			pc.recordFetchError(err)
 		}
+	}
 
-		for _, comment := range reviewComments {
-			commentTime := comment.CreatedAt.Time
-			username := comment.User.GetLogin()
+	// Adiciona dados de comentários às semanas existentes
+	pc.processWeeklyComments(weeklyComments, weeklyWeightedComments, weeklyRepoComments, weeklyRepoWeightedComments, weekStarts)
 
-			// Filtra usuários excluídos (bots, sonarqube, etc.)
-			if isExcludedUser(username) {
-				continue
-			}
+	fmt.Fprintf(pc.progress(), "💬 Total de comentários encontrados no período: %d\n", totalComments)
+	return nil
+}
 
-			if username == pr.User.GetLogin() {
-				fmt.Println("    ❗ Comentário do autor do PR ignorado:", username)
-				continue // Pula comentários feitos pelo autor do PR
-			}
+// prCommentsResult guarda os comentários válidos de um PR já pontuados
+type prCommentsResult struct {
+	comments []ScoredComment
+	errs     []error // dados que não puderam ser buscados (os comentários obtidos são mantidos)
+}
 
-			// Verifica se o review comment foi feito após o merge do PR
-			if pr.MergedAt != nil && commentTime.After(pr.MergedAt.Time) {
-				fmt.Printf("    ❗ Review comment pós-merge ignorado: %s (comentário: %s, merge: %s)\n",
-					username, commentTime.Format("02/01/2006 15:04"), pr.MergedAt.Time.Format("02/01/2006 15:04"))
-				continue
-			}
+// collectPRComments busca os comentários e review comments de um PR, aplica os filtros
+// e calcula a pontuação de cada um. É seguro chamá-lo em paralelo para PRs diferentes.
+func (pc *PRChampion) collectPRComments(ctx context.Context, pr *github.PullRequest) prCommentsResult {
+	var result prCommentsResult
 
-			// Determina a semana do comentário
-			weekStart := getWeekStart(pr.MergedAt.Time)
-			weekKey := weekStart.Format("2006-01-02")
+	repoOwner := pr.Base.Repo.Owner.GetLogin()
+	repoName := pr.Base.Repo.GetName()
+	repoFullName := repoKey(repoOwner, repoName)
+	prNumber := pr.GetNumber()
 
-			if weeklyComments[weekKey] == nil {
-				weeklyComments[weekKey] = make(map[string]int)
-				weeklyWeightedComments[weekKey] = make(map[string]float64)
-				weekStarts[weekKey] = weekStart
-			}
+	comments, err := pc.client.ListPRComments(ctx, repoOwner, repoName, prNumber)
+	if err != nil {
+		result.errs = append(result.errs, fmt.Errorf("erro ao buscar comentários do PR #%d em %s/%s: %v", prNumber, repoOwner, repoName, err))
+		return result
+	}
 
-			// Calcula pontuação ponderada baseada nas reações
-			commentScore := pc.calculateReviewCommentScore(ctx, repoOwner, repoName, comment.GetID(), pr.MergedAt.Time)
+	for _, comment := range comments {
+		commentTime := comment.CreatedAt.Time
+		username := comment.User.GetLogin()
 
-			weeklyComments[weekKey][username]++
-			weeklyWeightedComments[weekKey][username] += commentScore
-			totalComments++
+		if !acceptComment(pc.progress(), pr, username, commentTime, "Comentário") {
+			continue
+		}
+
+		// Calcula pontuação ponderada baseada nas reações
+		commentScore, err := pc.calculateCommentScore(ctx, repoOwner, repoName, comment.GetID(), pr.MergedAt.Time)
+		if err != nil {
+			result.errs = append(result.errs, err)
 		}
 
+		result.comments = append(result.comments, ScoredComment{
+			Username:      username,
+			Repo:          repoFullName,
+			PRNumber:      prNumber,
+			CommentType:   "issue",
+			WeightedScore: commentScore,
+			CreatedAt:     commentTime,
+		})
 	}
 
-	// Adiciona dados de comentários às semanas existentes
-	pc.processWeeklyComments(weeklyComments, weeklyWeightedComments, weekStarts)
+	reviewComments, err := pc.client.ListPRReviewComments(ctx, repoOwner, repoName, prNumber)
+	if err != nil {
+		result.errs = append(result.errs, fmt.Errorf("erro ao buscar review comments do PR #%d em %s/%s: %v", prNumber, repoOwner, repoName, err))
+		return result
+	}
 
-	fmt.Printf("💬 Total de comentários encontrados no período: %d\n", totalComments)
-	return nil
+	for _, comment := range reviewComments {
+		commentTime := comment.CreatedAt.Time
+		username := comment.User.GetLogin()
+
+		if !acceptComment(pc.progress(), pr, username, commentTime, "Review comment") {
+			continue
+		}
+
+		// Calcula pontuação ponderada baseada nas reações
+		commentScore, err := pc.calculateReviewCommentScore(ctx, repoOwner, repoName, comment.GetID(), pr.MergedAt.Time)
+		if err != nil {
+			result.errs = append(result.errs, err)
+		}
+
+		result.comments = append(result.comments, ScoredComment{
+			Username:      username,
+			Repo:          repoFullName,
+			PRNumber:      prNumber,
+			CommentType:   "review",
+			WeightedScore: commentScore,
+			CreatedAt:     commentTime,
+		})
+	}
+
+	return result
+}
+
+// acceptComment aplica os filtros de comentários: usuários excluídos, autor do PR e comentários pós-merge.
+// Os comentários ignorados são informados em progress
+func acceptComment(progress io.Writer, pr *github.PullRequest, username string, commentTime time.Time, kind string) bool {
+	// Filtra usuários excluídos (bots, sonarqube, etc.)
+	if isExcludedUser(username) {
+		return false
+	}
+
+	if username == pr.User.GetLogin() {
+		fmt.Fprintln(progress, "    ❗ Comentário do autor do PR ignorado:", username)
+		return false // Pula comentários feitos pelo autor do PR
+	}
+
+	// Verifica se o comentário foi feito após o merge do PR
+	if pr.MergedAt != nil && commentTime.After(pr.MergedAt.Time) {
+		fmt.Fprintf(progress, "    ❗ %s pós-merge ignorado: %s (comentário: %s, merge: %s)\n", kind,
+			username, commentTime.Format("02/01/2006 15:04"), pr.MergedAt.Time.Format("02/01/2006 15:04"))
+		return false
+	}
+
+	return true
 }
 
 // processWeeklyComments processa os comentários por semana e identifica vencedores
-func (pc *PRChampion) processWeeklyComments(weeklyComments map[string]map[string]int, weeklyWeightedComments map[string]map[string]float64, weekStarts map[string]time.Time) {
+func (pc *PRChampion) processWeeklyComments(weeklyComments map[string]map[string]int, weeklyWeightedComments map[string]map[string]float64,
+	weeklyRepoComments map[string]map[string]map[string]int, weeklyRepoWeightedComments map[string]map[string]map[string]float64,
+	weekStarts map[string]time.Time) {
+	calendar := pc.weekCalendar()
+
 	// Adiciona dados de comentários às semanas existentes ou cria novas semanas
 	for weekKey, userComments := range weeklyComments {
 		weekStart := weekStarts[weekKey]
 
-		// Encontra o vencedor da semana por comentários (contagem simples)
-		var commentWinner string
-		maxComments := 0
-		for user, count := range userComments {
-			if count > maxComments {
-				maxComments = count
-				commentWinner = user
-			}
-		}
-
-		// Encontra o vencedor da semana por pontuação ponderada
-		var weightedCommentWinner string
-		maxWeightedScore := 0.0
 		userWeightedComments := weeklyWeightedComments[weekKey]
-		for user, score := range userWeightedComments {
-			if score > maxWeightedScore {
-				maxWeightedScore = score
-				weightedCommentWinner = user
-			}
-		}
 
 		// Procura se já existe uma semana correspondente
 		found := false
 		for i := range pc.weeklyData {
 			if pc.weeklyData[i].StartDate.Equal(weekStart) {
 				pc.weeklyData[i].UserComments = userComments
-				pc.weeklyData[i].CommentWinner = commentWinner
 				pc.weeklyData[i].UserWeightedComments = userWeightedComments
-				pc.weeklyData[i].WeightedCommentWinner = weightedCommentWinner
+				pc.weeklyData[i].RepoComments = weeklyRepoComments[weekKey]
+				pc.weeklyData[i].RepoWeightedComments = weeklyRepoWeightedComments[weekKey]
 				found = true
 				break
 			}
@@ -308,15 +446,15 @@ func (pc *PRChampion) processWeeklyComments(weeklyComments map[string]map[string
 
 		// Se não encontrou, cria uma nova entrada semanal apenas para comentários
 		if !found {
-			weekEnd := weekStart.Add(6 * 24 * time.Hour)
+			weekEnd := calendar.PeriodEndOf(weekStart)
 			pc.weeklyData = append(pc.weeklyData, WeeklyData{
-				StartDate:             weekStart,
-				EndDate:               weekEnd,
-				UserPRs:               make(map[string]int),
-				UserComments:          userComments,
-				CommentWinner:         commentWinner,
-				UserWeightedComments:  userWeightedComments,
-				WeightedCommentWinner: weightedCommentWinner,
+				StartDate:            weekStart,
+				EndDate:              weekEnd,
+				UserPRs:              make(map[string]int),
+				UserComments:         userComments,
+				UserWeightedComments: userWeightedComments,
+				RepoComments:         weeklyRepoComments[weekKey],
+				RepoWeightedComments: weeklyRepoWeightedComments[weekKey],
 			})
 		}
 	}
@@ -325,48 +463,61 @@ func (pc *PRChampion) processWeeklyComments(weeklyComments map[string]map[string
 	sort.Slice(pc.weeklyData, func(i, j int) bool {
 		return pc.weeklyData[i].StartDate.Before(pc.weeklyData[j].StartDate)
 	})
+
+	// Recalcula os vencedores agora que os comentários também estão disponíveis
+	pc.resolveWeeklyWinners()
 }
 
 // processWeeklyData processa os PRs por semana
 func (pc *PRChampion) processWeeklyData(prs []*github.PullRequest) {
+	calendar := pc.weekCalendar()
+
 	// Agrupa PRs por semana
 	weeklyMap := make(map[string]map[string]int)
+	weeklyRepoMap := make(map[string]map[string]map[string]int) // weekKey -> repo -> username -> PRs
+	weeklyFirstMerge := make(map[string]map[string]time.Time)   // weekKey -> username -> primeiro merge
 	weekStarts := make(map[string]time.Time)
 
 	for _, pr := range prs {
 		mergedAt := pr.MergedAt.Time
-		weekStart := getWeekStart(mergedAt)
+		weekStart := calendar.PeriodStartOf(mergedAt)
 		weekKey := weekStart.Format("2006-01-02")
 
 		if weeklyMap[weekKey] == nil {
 			weeklyMap[weekKey] = make(map[string]int)
+			weeklyRepoMap[weekKey] = make(map[string]map[string]int)
+			weeklyFirstMerge[weekKey] = make(map[string]time.Time)
 			weekStarts[weekKey] = weekStart
 		}
 
 		username := pr.User.GetLogin()
 		weeklyMap[weekKey][username]++
+		if first, ok := weeklyFirstMerge[weekKey][username]; !ok || mergedAt.Before(first) {
+			weeklyFirstMerge[weekKey][username] = mergedAt
+		}
+		pc.addScorerPR(pr)
+
+		// Atribui o PR ao repositório base para as estatísticas por repositório
+		if pr.Base != nil && pr.Base.Repo != nil {
+			repo := repoKey(pr.Base.Repo.Owner.GetLogin(), pr.Base.Repo.GetName())
+			if weeklyRepoMap[weekKey][repo] == nil {
+				weeklyRepoMap[weekKey][repo] = make(map[string]int)
+			}
+			weeklyRepoMap[weekKey][repo][username]++
+		}
 	}
 
 	// Converte para slice de WeeklyData
 	for weekKey, userPRs := range weeklyMap {
 		weekStart := weekStarts[weekKey]
-		weekEnd := weekStart.Add(6 * 24 * time.Hour)
-
-		// Encontra o vencedor da semana
-		var winner string
-		maxPRs := 0
-		for user, count := range userPRs {
-			if count > maxPRs {
-				maxPRs = count
-				winner = user
-			}
-		}
+		weekEnd := calendar.PeriodEndOf(weekStart)
 
 		pc.weeklyData = append(pc.weeklyData, WeeklyData{
-			StartDate: weekStart,
-			EndDate:   weekEnd,
-			UserPRs:   userPRs,
-			Winner:    winner,
+			StartDate:    weekStart,
+			EndDate:      weekEnd,
+			UserPRs:      userPRs,
+			RepoData:     weeklyRepoMap[weekKey],
+			FirstMergeAt: weeklyFirstMerge[weekKey],
 		})
 	}
 
@@ -374,11 +525,19 @@ func (pc *PRChampion) processWeeklyData(prs []*github.PullRequest) {
 	sort.Slice(pc.weeklyData, func(i, j int) bool {
 		return pc.weeklyData[i].StartDate.Before(pc.weeklyData[j].StartDate)
 	})
+
+	pc.resolveWeeklyWinners()
 }
 
 // calculateUserStats calcula as estatísticas finais dos usuários
 func (pc *PRChampion) calculateUserStats() {
+	points := pc.scoringConfig().WeeklyPoints
+
 	for _, week := range pc.weeklyData {
+		prWinners := week.prWinners()
+		commentWinners := week.commentWinners()
+		weightedCommentWinners := week.weightedCommentWinners()
+
 		// Processa PRs
 		for username, prCount := range week.UserPRs {
 			if pc.userStats[username] == nil {
@@ -391,9 +550,23 @@ func (pc *PRChampion) calculateUserStats() {
 			stats := pc.userStats[username]
 			stats.PRsCount += prCount
 
-			if username == week.Winner {
+			if containsUser(prWinners, username) {
 				stats.WeeklyWins++
-				stats.TotalScore++
+				stats.TotalScore += points.PRChampion
+			}
+		}
+
+		// Processa PRs por repositório
+		for repo, repoUserPRs := range week.RepoData {
+			for username, prCount := range repoUserPRs {
+				stats := pc.userStats[username]
+				if stats == nil {
+					continue
+				}
+				if stats.RepoStats == nil {
+					stats.RepoStats = make(map[string]int)
+				}
+				stats.RepoStats[repo] += prCount
 			}
 		}
 
@@ -409,9 +582,9 @@ func (pc *PRChampion) calculateUserStats() {
 			stats := pc.userStats[username]
 			stats.CommentsCount += commentCount
 
-			if username == week.CommentWinner {
+			if containsUser(commentWinners, username) {
 				stats.CommentWeeklyWins++
-				stats.CommentScore++
+				stats.CommentScore += points.CommentChampion
 			}
 		}
 
@@ -427,169 +600,170 @@ func (pc *PRChampion) calculateUserStats() {
 			stats := pc.userStats[username]
 			stats.WeightedCommentScore += weightedScore
 
-			// Se for o vencedor da semana por qualidade de comentários, ganha 1 ponto
-			if username == week.WeightedCommentWinner {
+			// Se for o vencedor da semana por qualidade de comentários, ganha os pontos configurados
+			if containsUser(weightedCommentWinners, username) {
 				stats.WeightedCommentWeeklyWins++
-				stats.WeightedCommentWeeklyScore++
+				stats.WeightedCommentWeeklyScore += points.QualityChampion
 			}
 		}
 	}
 }
 
-// GenerateReport gera o relatório final
+// GenerateReport gera o relatório final em texto na saída padrão
 func (pc *PRChampion) GenerateReport() {
-	fmt.Printf("\n🏆 RELATÓRIO PR CHAMPION - %s a %s\n",
+	pc.WriteTextReport(os.Stdout)
+}
+
+// WriteTextReport escreve o relatório final em texto no writer informado
+func (pc *PRChampion) WriteTextReport(w io.Writer) {
+	fmt.Fprintf(w, "\n🏆 RELATÓRIO PR CHAMPION - %s a %s\n",
 		pc.startDate.Format("02/01/2006"), pc.endDate.Format("02/01/2006"))
 
 	// Lista dos repositórios analisados
-	fmt.Printf("📁 Repositórios analisados (%d):\n", len(pc.repositories))
+	fmt.Fprintf(w, "📁 Repositórios analisados (%d):\n", len(pc.repositories))
 	for _, repo := range pc.repositories {
-		fmt.Printf("   • %s/%s\n", repo.Owner, repo.Name)
+		fmt.Fprintf(w, "   • %s/%s\n", repo.Owner, repo.Name)
+	}
+	fmt.Fprintln(w)
+
+	// Aviso de relatório incompleto
+	if pc.Incomplete() {
+		fmt.Fprintf(w, "⚠️  RELATÓRIO INCOMPLETO: %d falha(s) ao buscar dados do GitHub; os rankings podem estar subestimados.\n", len(pc.fetchErrors))
+		for _, message := range limitFetchErrors(pc.fetchErrors, maxReportedFetchErrors) {
+			fmt.Fprintf(w, "   • %s\n", message)
+		}
+		fmt.Fprintln(w)
 	}
-	fmt.Println()
 
 	// Relatório semanal
-	fmt.Println("📅 RESUMO SEMANAL:")
-	fmt.Println(strings.Repeat("=", 60))
+	period := pc.weekCalendar().Period
+	fmt.Fprintf(w, "📅 %s:\n", strings.ToUpper(period.SummaryTitle()))
+	fmt.Fprintln(w, strings.Repeat("=", 60))
 
 	for _, week := range pc.weeklyData {
-		fmt.Printf("Semana: %s - %s\n",
+		fmt.Fprintf(w, "%s: %s - %s\n", period.Label(),
 			week.StartDate.Format("02/01"), week.EndDate.Format("02/01/2006"))
 
 		// Campeão por PRs
-		if week.Winner != "" {
-			fmt.Printf("🥇 Campeão PRs: %s\n", week.Winner)
+		if winners := week.prWinners(); len(winners) > 0 {
+			fmt.Fprintf(w, "🥇 Campeão PRs: %s\n", strings.Join(winners, ", "))
 			// Top 3 da semana por PRs
-			weekTop := pc.getTopUsersForWeek(week.UserPRs, 3)
+			weekTop := pc.getTopUsersForWeek(week, 3)
 			for i, user := range weekTop {
 				medal := []string{"🥇", "🥈", "🥉"}[i]
-				fmt.Printf("   %s %s: %d PRs\n", medal, user.Username, user.PRsCount)
+				fmt.Fprintf(w, "   %s %s: %d PRs\n", medal, user.Username, user.PRsCount)
 			}
 		}
 
 		// Campeão por qualidade de comentários (pontuação ponderada)
-		if week.WeightedCommentWinner != "" {
-			fmt.Printf("⭐ Campeão Qualidade: %s\n", week.WeightedCommentWinner)
+		if winners := week.weightedCommentWinners(); len(winners) > 0 {
+			fmt.Fprintf(w, "⭐ Campeão Qualidade: %s\n", strings.Join(winners, ", "))
 			// Top 3 da semana por pontuação ponderada
-			weekTopWeighted := pc.getTopUsersForWeekWeighted(week.UserWeightedComments, 3)
+			weekTopWeighted := pc.getTopUsersForWeekWeighted(week, 3)
 			for i, user := range weekTopWeighted {
 				medal := []string{"🥇", "🥈", "🥉"}[i]
-				fmt.Printf("   %s %s: %.1f pontos\n", medal, user.Username, user.WeightedCommentScore)
+				fmt.Fprintf(w, "   %s %s: %.1f pontos\n", medal, user.Username, user.WeightedCommentScore)
 			}
 		}
 
-		fmt.Println()
-	}
-
-	// Ranking geral por pontuação
-	fmt.Println("🏅 RANKING GERAL POR PONTUAÇÃO:")
-	fmt.Println(strings.Repeat("=", 60))
-
-	topUsers := pc.getTopUsersByScore(5)
-	for i, user := range topUsers {
-		position := i + 1
-		medal := ""
-		switch position {
-		case 1:
-			medal = "🥇"
-		case 2:
-			medal = "🥈"
-		case 3:
-			medal = "🥉"
-		case 4:
-			medal = "🏅"
-		case 5:
-			medal = "🎖️"
-		}
-
-		fmt.Printf("%s %d° lugar: %s\n", medal, position, user.Username)
-		fmt.Printf("   📊 Pontuação: %d pontos\n", user.TotalScore)
-		fmt.Printf("   🏆 Vitórias semanais: %d\n", user.WeeklyWins)
-		fmt.Printf("   📋 Total de PRs: %d\n\n", user.PRsCount)
+		fmt.Fprintln(w)
 	}
+	fmt.Fprintf(w, "⚖️  Política de desempate: %s\n\n", pc.activeTiePolicy())
 
-	// Ranking por pontuação semanal de qualidade de comentários
-	fmt.Println("🏅 RANKING SEMANAL POR QUALIDADE DOS COMENTÁRIOS:")
-	fmt.Println(strings.Repeat("=", 60))
+	// Rankings dos Scorers selecionados (--scorer)
+	for _, scorer := range pc.activeScorers() {
+		fmt.Fprintf(w, "%s:\n", scorer.Title())
+		fmt.Fprintln(w, strings.Repeat("=", 60))
 
-	topWeightedCommentWeeklyUsers := pc.getTopUsersByWeightedCommentWeeklyScore(5)
-	if len(topWeightedCommentWeeklyUsers) == 0 {
-		fmt.Println("   Nenhuma vitória semanal por qualidade de comentários foi registrada no período analisado.")
-	} else {
-		for i, user := range topWeightedCommentWeeklyUsers {
-			position := i + 1
-			medal := ""
-			switch position {
-			case 1:
-				medal = "🥇"
-			case 2:
-				medal = "🥈"
-			case 3:
-				medal = "🥉"
-			case 4:
-				medal = "🏅"
-			case 5:
-				medal = "🎖️"
-			}
-
-			fmt.Printf("%s %d° lugar: %s\n", medal, position, user.Username)
-			fmt.Printf("   🏅 Pontuação semanal: %d pontos\n", user.WeightedCommentWeeklyScore)
-			fmt.Printf("   🏆 Vitórias semanais (qualidade): %d\n", user.WeightedCommentWeeklyWins)
-			fmt.Printf("   ⭐ Pontuação total com reações: %.1f pontos\n\n", user.WeightedCommentScore)
+		entries := pc.Leaderboard(scorer, 5)
+		if len(entries) == 0 {
+			fmt.Fprintln(w, "   Nenhuma pontuação registrada no período analisado.")
 		}
-	}
 
-	// Top 5 por número total de PRs
-	fmt.Println("📈 TOP 5 POR TOTAL DE PRS:")
-	fmt.Println(strings.Repeat("=", 60))
+		// Scorers detalhados exibem um bloco por usuário, já separado por linha em branco
+		detailed, ok := scorer.(DetailedScorer)
+		for i, entry := range entries {
+			if !ok {
+				fmt.Fprintf(w, "%s %d° lugar: %s - %s %s\n", rankingMedal(i), i+1, entry.Username, formatScore(entry.Score), scorer.Unit())
+				continue
+			}
 
-	topByPRs2 := pc.getTopUsersByPRs(5)
-	for i, user := range topByPRs2 {
-		position := i + 1
-		medal := []string{"🥇", "🥈", "🥉", "🏅", "🎖️"}[i]
-		fmt.Printf("%s %d° lugar: %s - %d PRs\n", medal, position, user.Username, user.PRsCount)
+			fmt.Fprintf(w, "%s %d° lugar: %s\n", rankingMedal(i), i+1, entry.Username)
+			for _, detail := range detailed.Details(pc.statsFor(entry.Username)) {
+				fmt.Fprintf(w, "   %s %s: %s\n", detail.Icon, detail.Label, detail.Text())
+			}
+			fmt.Fprintln(w)
+		}
+		if !ok || len(entries) == 0 {
+			fmt.Fprintln(w)
+		}
 	}
-	fmt.Println()
 
-	// Top 5 por número total de comentários
-	fmt.Println("💬 TOP 5 POR QUALIDADE DE COMENTÁRIOS:")
-	fmt.Println(strings.Repeat("=", 60))
+	// Ranking por repositório
+	repoNames := pc.getRepositoryNames()
+	if len(repoNames) > 0 {
+		fmt.Fprintln(w, "📁 RANKING POR REPOSITÓRIO:")
+		fmt.Fprintln(w, strings.Repeat("=", 60))
+
+		for _, repo := range repoNames {
+			fmt.Fprintf(w, "📦 %s\n", repo)
+
+			// Campeões semanais do repositório
+			hasWinners := false
+			for _, week := range pc.weeklyData {
+				winners := week.RepoWinners[repo]
+				if len(winners) == 0 {
+					continue
+				}
+				hasWinners = true
+				fmt.Fprintf(w, "   🥇 %s %s - %s: %s (%d PRs)\n", period.Label(),
+					week.StartDate.Format("02/01"), week.EndDate.Format("02/01/2006"),
+					strings.Join(winners, ", "), week.RepoData[repo][winners[0]])
+			}
+			if !hasWinners {
+				fmt.Fprintln(w, "   Nenhum PR mergeado no período analisado.")
+				fmt.Fprintln(w)
+				continue
+			}
 
-	topByComments := pc.getTopUsersByWeightedCommentScore(5)
-	if len(topByComments) == 0 {
-		fmt.Println("   Nenhum comentário encontrado no período analisado.")
-	} else {
-		for i, user := range topByComments {
-			position := i + 1
-			medal := []string{"🥇", "🥈", "🥉", "🏅", "🎖️"}[i]
-			fmt.Printf("%s %d° lugar: %s - %.2f comentários\n", medal, position, user.Username, user.WeightedCommentScore)
+			// Top contribuidores do repositório
+			fmt.Fprintln(w, "   📈 Top contribuidores:")
+			for i, user := range pc.getTopUsersForRepo(repo, 3) {
+				fmt.Fprintf(w, "   %s %s: %d PRs\n", rankingMedal(i), user.Username, user.PRsCount)
+			}
+			fmt.Fprintln(w)
 		}
 	}
-	fmt.Println()
 
 	// Estatísticas do cache
-	fmt.Println("📈 ESTATÍSTICAS DO CACHE:")
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println("💾 Sistema de cache em banco SQLite ativo")
-	fmt.Println("📋 Cache de comentários e reações: 7 dias")
-	fmt.Println("🗂️  Local do banco: ./data/comments.db")
-	fmt.Println("💡 Use --clear-database para limpar todo o cache")
+	fmt.Fprintln(w, "📈 ESTATÍSTICAS DO CACHE:")
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+	if pc.offline {
+		fmt.Fprintln(w, "📴 Modo offline: dados lidos apenas do banco SQLite local, sem chamadas à API")
+	} else {
+		fmt.Fprintln(w, "💾 Sistema de cache em banco SQLite ativo")
+		fmt.Fprintf(w, "📋 Cache de comentários e reações: %s\n", pc.freshnessPolicy())
+	}
+	if pc.dbPath != "" {
+		fmt.Fprintf(w, "🗂️  Local do banco: %s\n", pc.dbPath)
+	}
+	pc.writeUsageSection(w)
+	if !pc.offline {
+		fmt.Fprintln(w, "💡 Use --clear-database para limpar todo o cache")
+	}
 }
 
-// getTopUsersForWeek retorna os top usuários de uma semana específica
-func (pc *PRChampion) getTopUsersForWeek(userPRs map[string]int, limit int) []UserStats {
+// getTopUsersForWeek retorna os top usuários de uma semana específica, com os empates
+// ordenados pela mesma política usada para escolher o campeão
+func (pc *PRChampion) getTopUsersForWeek(week WeeklyData, limit int) []UserStats {
 	var users []UserStats
-	for username, prCount := range userPRs {
+	for _, username := range rankUsers(intScores(week.UserPRs), pc.weekTiePolicy(week), week.prTieBreakers()...) {
 		users = append(users, UserStats{
 			Username: username,
-			PRsCount: prCount,
+			PRsCount: week.UserPRs[username],
 		})
 	}
 
-	sort.Slice(users, func(i, j int) bool {
-		return users[i].PRsCount > users[j].PRsCount
-	})
-
 	if len(users) > limit {
 		users = users[:limit]
 	}
@@ -597,59 +771,17 @@ func (pc *PRChampion) getTopUsersForWeek(userPRs map[string]int, limit int) []Us
 	return users
 }
 
-// getTopUsersForWeekWeighted retorna os top usuários por pontuação ponderada de uma semana específica
-func (pc *PRChampion) getTopUsersForWeekWeighted(userWeightedComments map[string]float64, limit int) []UserStats {
+// getTopUsersForWeekWeighted retorna os top usuários por pontuação ponderada de uma semana específica,
+// com os empates ordenados pela mesma política usada para escolher o campeão
+func (pc *PRChampion) getTopUsersForWeekWeighted(week WeeklyData, limit int) []UserStats {
 	var users []UserStats
-	for username, weightedScore := range userWeightedComments {
+	for _, username := range rankUsers(week.UserWeightedComments, pc.weekTiePolicy(week), week.weightedCommentTieBreakers()...) {
 		users = append(users, UserStats{
 			Username:             username,
-			WeightedCommentScore: weightedScore,
+			WeightedCommentScore: week.UserWeightedComments[username],
 		})
 	}
 
-	sort.Slice(users, func(i, j int) bool {
-		return users[i].WeightedCommentScore > users[j].WeightedCommentScore
-	})
-
-	if len(users) > limit {
-		users = users[:limit]
-	}
-
-	return users
-}
-
-// getTopUsersByScore retorna os top usuários por pontuação
-func (pc *PRChampion) getTopUsersByScore(limit int) []*UserStats {
-	var users []*UserStats
-	for _, stats := range pc.userStats {
-		users = append(users, stats)
-	}
-
-	sort.Slice(users, func(i, j int) bool {
-		if users[i].TotalScore == users[j].TotalScore {
-			return users[i].PRsCount > users[j].PRsCount
-		}
-		return users[i].TotalScore > users[j].TotalScore
-	})
-
-	if len(users) > limit {
-		users = users[:limit]
-	}
-
-	return users
-}
-
-// getTopUsersByPRs retorna os top usuários por número de PRs
-func (pc *PRChampion) getTopUsersByPRs(limit int) []*UserStats {
-	var users []*UserStats
-	for _, stats := range pc.userStats {
-		users = append(users, stats)
-	}
-
-	sort.Slice(users, func(i, j int) bool {
-		return users[i].PRsCount > users[j].PRsCount
-	})
-
 	if len(users) > limit {
 		users = users[:limit]
 	}
@@ -657,86 +789,50 @@ func (pc *PRChampion) getTopUsersByPRs(limit int) []*UserStats {
 	return users
 }
 
-// getTopUsersByComments retorna os top usuários por número de comentários
-func (pc *PRChampion) getTopUsersByComments(limit int) []*UserStats {
-	var users []*UserStats
-	for _, stats := range pc.userStats {
-		if stats.CommentsCount > 0 { // Apenas usuários que fizeram comentários
-			users = append(users, stats)
-		}
-	}
-
-	sort.Slice(users, func(i, j int) bool {
-		return users[i].CommentsCount > users[j].CommentsCount
-	})
-
-	if len(users) > limit {
-		users = users[:limit]
-	}
-
-	return users
-}
-
-// getTopUsersByCommentScore retorna os top usuários por pontuação de comentários
-func (pc *PRChampion) getTopUsersByCommentScore(limit int) []*UserStats {
-	var users []*UserStats
-	for _, stats := range pc.userStats {
-		if stats.CommentScore > 0 { // Apenas usuários que ganharam pontos por comentários
-			users = append(users, stats)
-		}
-	}
-
-	sort.Slice(users, func(i, j int) bool {
-		if users[i].CommentScore == users[j].CommentScore {
-			return users[i].CommentsCount > users[j].CommentsCount
-		}
-		return users[i].CommentScore > users[j].CommentScore
-	})
-
-	if len(users) > limit {
-		users = users[:limit]
-	}
-
-	return users
-}
-
-// getTopUsersByWeightedCommentScore retorna os top usuários por pontuação ponderada de comentários
-func (pc *PRChampion) getTopUsersByWeightedCommentScore(limit int) []*UserStats {
-	var users []*UserStats
-	for _, stats := range pc.userStats {
-		if stats.WeightedCommentScore > 0 { // Apenas usuários com pontuação positiva
-			users = append(users, stats)
+// getRepositoryNames retorna os repositórios (owner/repo) na ordem em que foram configurados,
+// seguidos de qualquer outro repositório que apareça nos dados semanais
+func (pc *PRChampion) getRepositoryNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, repo := range pc.repositories {
+		name := repoKey(repo.Owner, repo.Name)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
 		}
 	}
 
-	sort.Slice(users, func(i, j int) bool {
-		if users[i].WeightedCommentScore == users[j].WeightedCommentScore {
-			return users[i].CommentsCount > users[j].CommentsCount
+	var extra []string
+	for _, week := range pc.weeklyData {
+		for name := range week.RepoData {
+			if !seen[name] {
+				seen[name] = true
+				extra = append(extra, name)
+			}
 		}
-		return users[i].WeightedCommentScore > users[j].WeightedCommentScore
-	})
-
-	if len(users) > limit {
-		users = users[:limit]
 	}
+	sort.Strings(extra)
 
-	return users
+	return append(names, extra...)
 }
 
-// getTopUsersByWeightedCommentWeeklyScore retorna os top usuários por pontuação semanal de qualidade de comentários
-func (pc *PRChampion) getTopUsersByWeightedCommentWeeklyScore(limit int) []*UserStats {
-	var users []*UserStats
+// getTopUsersForRepo retorna os top usuários por número de PRs em um repositório
+func (pc *PRChampion) getTopUsersForRepo(repo string, limit int) []UserStats {
+	var users []UserStats
 	for _, stats := range pc.userStats {
-		if stats.WeightedCommentWeeklyScore > 0 { // Apenas usuários que ganharam pontos semanais por qualidade
-			users = append(users, stats)
+		if count := stats.RepoStats[repo]; count > 0 {
+			users = append(users, UserStats{
+				Username: stats.Username,
+				PRsCount: count,
+			})
 		}
 	}
 
 	sort.Slice(users, func(i, j int) bool {
-		if users[i].WeightedCommentWeeklyScore == users[j].WeightedCommentWeeklyScore {
-			return users[i].WeightedCommentWeeklyWins > users[j].WeightedCommentWeeklyWins
+		if users[i].PRsCount == users[j].PRsCount {
+			return users[i].Username < users[j].Username
 		}
-		return users[i].WeightedCommentWeeklyScore > users[j].WeightedCommentWeeklyScore
+		return users[i].PRsCount > users[j].PRsCount
 	})
 
 	if len(users) > limit {
@@ -774,70 +870,65 @@ func isExcludedUser(username string) bool {
 	return strings.HasSuffix(usernameLower, "[bot]")
 }
 
-// calculateCommentScore calcula a pontuação de um comentário baseada em suas reações
-func (pc *PRChampion) calculateCommentScore(ctx context.Context, repoOwner, repoName string, commentID int64, mergedAt time.Time) float64 {
+// calculateCommentScore calcula a pontuação de um comentário baseada em suas reações.
+// Se as reações não puderem ser buscadas, retorna a pontuação de fallback junto com o erro.
+func (pc *PRChampion) calculateCommentScore(ctx context.Context, repoOwner, repoName string, commentID int64, mergedAt time.Time) (float64, error) {
 	// Busca as reações do comentário
 	reactions, err := pc.client.ListIssueCommentReactions(ctx, repoOwner, repoName, commentID)
 	if err != nil {
-		// Se não conseguir buscar reações, conta como 0 pontos
-		return 0
+		// Se não conseguir buscar reações, conta apenas a pontuação base
+		return pc.scoringConfig().BaseCommentScore, fmt.Errorf("erro ao buscar reações do comentário %d em %s/%s: %v", commentID, repoOwner, repoName, err)
 	}
 
-	return pc.calculateScoreFromReactions(reactions, mergedAt)
+	return pc.calculateScoreFromReactions(reactions, mergedAt), nil
 }
 
 // calculateScoreFromReactions calcula a pontuação baseada em uma lista de reações
 func (pc *PRChampion) calculateScoreFromReactions(reactions []*github.Reaction, mergedAt time.Time) float64 {
-	score := 1.0 // Pontuação base do comentário
+	cfg := pc.scoringConfig()
+	score := cfg.BaseCommentScore // Pontuação base do comentário
 
 	for _, reaction := range reactions {
 		if reaction.GetCreatedAt().Time.After(mergedAt) {
 			continue // Ignora reações feitas após o merge do PR
 		}
-		switch reaction.GetContent() {
-		case "+1": // 👍
-			score += 2.0 // +2 adicional (total = 3)
-		case "-1": // 👎
-			score -= 2.0 // -2 para neutralizar o ponto base e ainda penalizar (-1)
-		case "heart", "hooray", "rocket": // ❤️ 🎉 🚀
-			score += 0.5 // Reações positivas menores
-		case "confused", "eyes": // 😕 👀
-			score -= 0.5 // Reações neutras/negativas menores
-		}
+		score += cfg.ReactionWeight(reaction.GetContent())
 	}
 
-	// Garante que a pontuação mínima seja -1 (para comentários muito mal recebidos)
-	if score < -1.0 {
-		score = -1.0
-	}
-
-	return score
+	// Aplica o piso e o teto configurados (padrão: mínimo de -1 para comentários muito mal recebidos)
+	return cfg.ClampCommentScore(score)
 }
 
-// calculateReviewCommentScore calcula a pontuação de um review comment baseada em suas reações
-func (pc *PRChampion) calculateReviewCommentScore(ctx context.Context, repoOwner, repoName string, commentID int64, mergedAt time.Time) float64 {
+// calculateReviewCommentScore calcula a pontuação de um review comment baseada em suas reações.
+// Se as reações não puderem ser buscadas, retorna a pontuação de fallback junto com o erro.
+func (pc *PRChampion) calculateReviewCommentScore(ctx context.Context, repoOwner, repoName string, commentID int64, mergedAt time.Time) (float64, error) {
 	// Busca as reações do review comment
 	reactions, err := pc.client.ListPullRequestCommentReactions(ctx, repoOwner, repoName, commentID)
 	if err != nil {
-		// Se não conseguir buscar reações, conta como 1 ponto normal
-		return 1.0
+		// Se não conseguir buscar reações, conta apenas a pontuação base
+		return pc.scoringConfig().BaseCommentScore, fmt.Errorf("erro ao buscar reações do review comment %d em %s/%s: %v", commentID, repoOwner, repoName, err)
 	}
 
-	return pc.calculateScoreFromReactions(reactions, mergedAt)
+	return pc.calculateScoreFromReactions(reactions, mergedAt), nil
 }
 
-// getWeekStart retorna o início da semana (segunda-feira)
+// getWeekStart retorna o início da semana (segunda-feira, em UTC)
 func getWeekStart(t time.Time) time.Time {
-	weekday := t.Weekday()
-	if weekday == 0 {
-		weekday = 7 // Domingo = 7
-	}
-	daysBack := int(weekday) - 1
-	return t.Add(-time.Duration(daysBack) * 24 * time.Hour).Truncate(24 * time.Hour)
+	return DefaultWeekCalendar().WeekStartOf(t)
 }
 
-// parseDate converte string de data no formato DD/MM/YYYY para time.Time
+// repoKey retorna o identificador owner/repo usado nos mapas por repositório
+func repoKey(owner, name string) string {
+	return owner + "/" + name
+}
+
+// parseDate converte string de data no formato DD/MM/YYYY para time.Time (em UTC)
 func parseDate(dateStr string) (time.Time, error) {
+	return parseDateInLocation(dateStr, time.UTC)
+}
+
+// parseDateInLocation converte string de data para a meia-noite do dia no fuso informado
+func parseDateInLocation(dateStr string, location *time.Location) (time.Time, error) {
 	layouts := []string{
 		"02/01/2006",
 		"2006-01-02",
@@ -845,7 +936,7 @@ func parseDate(dateStr string) (time.Time, error) {
 	}
 
 	for _, layout := range layouts {
-		if t, err := time.Parse(layout, dateStr); err == nil {
+		if t, err := time.ParseInLocation(layout, dateStr, location); err == nil {
 			return t, nil
 		}
 	}
@@ -867,17 +958,7 @@ func parseRepositories(repoStrings []string) ([]Repository, error) {
 
 		if colonIndex != -1 {
 			repoPath = repoStr[:colonIndex]
-			branchesStr := strings.TrimSpace(repoStr[colonIndex+1:])
-
-			if branchesStr != "" {
-				branchList := strings.Split(branchesStr, "|")
-				for _, branch := range branchList {
-					branch = strings.TrimSpace(branch)
-					if branch != "" {
-						productionBranches = append(productionBranches, branch)
-					}
-				}
-			}
+			productionBranches = splitBranchPatterns(repoStr[colonIndex+1:])
 		} else {
 			repoPath = repoStr
 		}
@@ -886,6 +967,10 @@ func parseRepositories(repoStrings []string) ([]Repository, error) {
 			productionBranches = []string{"main"}
 		}
 
+		if err := validateBranchPatterns(productionBranches); err != nil {
+			return nil, fmt.Errorf("formato de repositório inválido: %s (%v)", repoStr, err)
+		}
+
 		// Divide owner/repo - só considera as primeiras duas partes separadas por '/'
 		slashIndex := strings.Index(repoPath, "/")
 		if slashIndex == -1 || slashIndex == len(repoPath)-1 {
@@ -895,11 +980,23 @@ func parseRepositories(repoStrings []string) ([]Repository, error) {
 		owner := strings.TrimSpace(repoPath[:slashIndex])
 		repo := strings.TrimSpace(repoPath[slashIndex+1:])
 
+		// Repositório qualificado com o host do GitHub Enterprise Server: host/owner/repo
+		host := ""
+		if parts := strings.Split(repoPath, "/"); len(parts) == 3 {
+			host = strings.ToLower(strings.TrimSpace(parts[0]))
+			owner = strings.TrimSpace(parts[1])
+			repo = strings.TrimSpace(parts[2])
+			if host == "" {
+				return nil, fmt.Errorf("formato de repositório inválido: %s (use host/owner/repo)", repoStr)
+			}
+		}
+
 		if owner == "" || repo == "" {
 			return nil, fmt.Errorf("formato de repositório inválido: %s (owner e repo não podem ser vazios)", repoStr)
 		}
 
 		repositories = append(repositories, Repository{
+			Host:               host,
 			Owner:              owner,
 			Name:               repo,
 			ProductionBranches: productionBranches,
@@ -930,144 +1027,349 @@ Formato das branches de produção:
   • owner/repo (usa 'main' como padrão)
   • owner/repo:branch (especifica branch customizada)
   • owner/repo:branch1|branch2|branch3 (múltiplas branches aceitas - separador |)
-  • owner/repo:feat/rebrand-main|main (suporta branches com barras)`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// Carrega variáveis do arquivo .env se existir
-		if err := godotenv.Load(); err != nil {
-			// Não é um erro fatal se o arquivo .env não existir
-			if !os.IsNotExist(err) {
-				fmt.Printf("⚠️  Aviso: Erro ao carregar .env: %v\n", err)
-			}
-		} else {
-			fmt.Println("✅ Arquivo .env carregado com sucesso")
+  • owner/repo:feat/rebrand-main|main (suporta branches com barras)
+  • ghe.corp.com/owner/repo:main (repositório em um GitHub Enterprise Server)
+  • owner/repo:default|release/*|re:^hotfix/\d+$ (branch padrão do repo, glob e regex)
+  • owner/repo:main|re:^(release|hotfix)/ (re: vai até o fim da lista e aceita |)`,
+	Run: runReport,
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Gera o relatório de PRs mergeados no formato escolhido",
+	Long: `Gera o relatório do PR Champion no formato escolhido (text, json, markdown, html ou csv).
+
+Exemplo:
+  pr-champion report --format html --out report.html`,
+	Run: runReport,
+}
+
+// runReport executa a análise completa e escreve o relatório
+func runReport(cmd *cobra.Command, args []string) {
+	token, _ := cmd.Flags().GetString("token")
+	owner, _ := cmd.Flags().GetString("owner")
+	repo, _ := cmd.Flags().GetString("repo")
+	reposList, _ := cmd.Flags().GetStringSlice("repos")
+	startDateStr, _ := cmd.Flags().GetString("start")
+	endDateStr, _ := cmd.Flags().GetString("end")
+	daysBack, _ := cmd.Flags().GetInt("days")
+	clearDatabase, _ := cmd.Flags().GetBool("clear-database")
+	formatFlag, _ := cmd.Flags().GetString("format")
+	outPath, _ := cmd.Flags().GetString("out")
+	scoringConfigPath, _ := cmd.Flags().GetString("scoring-config")
+	scorerNames, _ := cmd.Flags().GetStringSlice("scorer")
+	tiePolicyFlag, _ := cmd.Flags().GetString("tie-policy")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	weekStartFlag, _ := cmd.Flags().GetString("week-start")
+	periodFlag, _ := cmd.Flags().GetString("period")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	offline, _ := cmd.Flags().GetBool("offline")
+	cacheTTL, _ := cmd.Flags().GetString("cache-ttl")
+	freezeAfterMerge, _ := cmd.Flags().GetString("freeze-after-merge")
+	refreshCurrentWeek, _ := cmd.Flags().GetBool("refresh-current-week")
+	apiFlag, _ := cmd.Flags().GetString("api")
+	githubURL, _ := cmd.Flags().GetString("github-url")
+	dbPath, _ := cmd.Flags().GetString("database")
+	appID, _ := cmd.Flags().GetInt64("app-id")
+	appInstallationID, _ := cmd.Flags().GetInt64("app-installation-id")
+	appPrivateKey, _ := cmd.Flags().GetString("app-private-key")
+	orgSpec, _ := cmd.Flags().GetString("org")
+	topics, _ := cmd.Flags().GetStringSlice("topic")
+	includePatterns, _ := cmd.Flags().GetStringSlice("include")
+	excludePatterns, _ := cmd.Flags().GetStringSlice("exclude")
+	skipArchived, _ := cmd.Flags().GetBool("skip-archived")
+
+	format, err := normalizeReportFormat(formatFlag)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// Saída do relatório: stdout por padrão ou o arquivo informado em --out, aberto antes da busca
+	// para que um caminho inválido falhe logo
+	var reportOutput io.Writer = os.Stdout
+	var reportFile *os.File
+	if outPath != "" {
+		reportFile, err = openReportFile(outPath)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
 		}
+		defer reportFile.Close()
+		reportOutput = reportFile
+	}
 
-		token, _ := cmd.Flags().GetString("token")
-		owner, _ := cmd.Flags().GetString("owner")
-		repo, _ := cmd.Flags().GetString("repo")
-		reposList, _ := cmd.Flags().GetStringSlice("repos")
-		startDateStr, _ := cmd.Flags().GetString("start")
-		endDateStr, _ := cmd.Flags().GetString("end")
-		daysBack, _ := cmd.Flags().GetInt("days")
-		clearDatabase, _ := cmd.Flags().GetBool("clear-database")
+	// Mensagens de progresso vão para stderr para não poluir a saída estruturada
+	var progress io.Writer = os.Stdout
+	if outPath == "" && format != ReportFormatText {
+		progress = os.Stderr
+	}
 
-		// Validação do token
+	// Carrega variáveis do arquivo .env se existir
+	if err := godotenv.Load(); err != nil {
+		// Não é um erro fatal se o arquivo .env não existir
+		if !os.IsNotExist(err) {
+			fmt.Fprintf(progress, "⚠️  Aviso: Erro ao carregar .env: %v\n", err)
+		}
+	} else {
+		fmt.Fprintln(progress, "✅ Arquivo .env carregado com sucesso")
+	}
+
+	// Carrega a configuração de pontuação, se informada
+	scoringConfig := DefaultScoringConfig()
+	if scoringConfigPath != "" {
+		scoringConfig, err = LoadScoringConfig(scoringConfigPath)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Fprintf(progress, "⚖️  Configuração de pontuação carregada de %s\n", scoringConfigPath)
+	}
+
+	// Resolve os rankings selecionados
+	scorers, err := LookupScorers(scorerNames)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	tiePolicy, err := ParseTiePolicy(tiePolicyFlag)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// Calendário usado para agrupar PRs e comentários em períodos
+	calendar, err := NewWeekCalendar(timezone, weekStartFlag)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	calendar.Period, err = ParsePeriod(periodFlag, calendar.Location)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// Política de validade do cache; a "semana atual" segue o calendário do relatório
+	freshness, err := ParseFreshnessPolicy(cacheTTL, freezeAfterMerge, refreshCurrentWeek)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	freshness.CurrentWeekStart = calendar.WeekStartOf(time.Now())
+
+	if concurrency < 1 {
+		log.Fatal("❌ --concurrency deve ser maior ou igual a 1")
+	}
+
+	if offline && clearDatabase {
+		log.Fatal("❌ --clear-database não pode ser usado com --offline (o banco local é a única fonte dos dados)")
+	}
+
+	// Autenticação como GitHub App, alternativa ao token pessoal
+	var githubApp *infrastructure.GithubAppCredentials
+	if !offline {
+		githubApp, err = resolveGithubApp(appID, appInstallationID, appPrivateKey)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	}
+
+	// Validação do token (dispensado no modo offline ou com GitHub App)
+	if token == "" && !offline && githubApp == nil {
+		token = os.Getenv("GITHUB_TOKEN")
 		if token == "" {
-			token = os.Getenv("GITHUB_TOKEN")
-			if token == "" {
-				log.Fatal("❌ Token do GitHub é obrigatório. Use --token ou defina GITHUB_TOKEN")
-			}
+			log.Fatal("❌ Token do GitHub é obrigatório. Use --token, defina GITHUB_TOKEN ou configure uma GitHub App (--app-id)")
 		}
+	}
 
-		// Construir lista de repositórios
-		var repositories []Repository
-		var err error
+	// Construir lista de repositórios
+	var repositories []Repository
 
-		if len(reposList) > 0 {
-			// Usar lista de repositórios da flag --repos
-			repositories, err = parseRepositories(reposList)
-			if err != nil {
-				log.Fatalf("❌ Erro ao parsear repositórios da flag: %v", err)
+	if len(reposList) > 0 {
+		// Usar lista de repositórios da flag --repos
+		repositories, err = parseRepositories(reposList)
+		if err != nil {
+			log.Fatalf("❌ Erro ao parsear repositórios da flag: %v", err)
+		}
+	} else if owner != "" && repo != "" {
+		// Usar repositório único (compatibilidade)
+		repositories = []Repository{{Owner: owner, Name: repo, ProductionBranches: []string{"main"}}}
+	} else {
+		// Tentar ler da variável de ambiente GITHUB_REPOS
+		envRepos := os.Getenv("GITHUB_REPOS")
+		if envRepos != "" {
+			repoStrings := strings.Split(envRepos, ",")
+			// Remove espaços em branco
+			for i, repo := range repoStrings {
+				repoStrings[i] = strings.TrimSpace(repo)
 			}
-		} else if owner != "" && repo != "" {
-			// Usar repositório único (compatibilidade)
-			repositories = []Repository{{Owner: owner, Name: repo, ProductionBranches: []string{"main"}}}
-		} else {
-			// Tentar ler da variável de ambiente GITHUB_REPOS
-			envRepos := os.Getenv("GITHUB_REPOS")
-			if envRepos != "" {
-				repoStrings := strings.Split(envRepos, ",")
-				// Remove espaços em branco
-				for i, repo := range repoStrings {
-					repoStrings[i] = strings.TrimSpace(repo)
-				}
-				repositories, err = parseRepositories(repoStrings)
-				if err != nil {
-					log.Fatalf("❌ Erro ao parsear repositórios da variável GITHUB_REPOS: %v", err)
-				}
-				fmt.Printf("📋 Usando repositórios da variável GITHUB_REPOS: %s\n", envRepos)
-			} else {
-				log.Fatal("❌ Especifique repositórios usando:\n" +
-					"   • --repos owner1/repo1:main|master,owner2/repo2\n" +
-					"   • --owner e --repo (repositório único)\n" +
-					"   • Variável GITHUB_REPOS=owner1/repo1:main|master,owner2/repo2")
+			repositories, err = parseRepositories(repoStrings)
+			if err != nil {
+				log.Fatalf("❌ Erro ao parsear repositórios da variável GITHUB_REPOS: %v", err)
 			}
+			fmt.Fprintf(progress, "📋 Usando repositórios da variável GITHUB_REPOS: %s\n", envRepos)
+		} else if orgSpec == "" {
+			log.Fatal("❌ Especifique repositórios usando:\n" +
+				"   • --repos owner1/repo1:main|master,owner2/repo2\n" +
+				"   • --owner e --repo (repositório único)\n" +
+				"   • Variável GITHUB_REPOS=owner1/repo1:main|master,owner2/repo2\n" +
+				"   • --org minha-org (todos os repositórios da organização)")
 		}
+	}
 
-		var startDate, endDate time.Time
+	var startDate, endDate time.Time
 
-		// Se foi especificado --days, calcula as datas automaticamente
-		if daysBack > 0 {
-			endDate = time.Now()
-			startDate = endDate.Add(-time.Duration(daysBack) * 24 * time.Hour)
+	// Se foi especificado --days, calcula as datas automaticamente
+	if daysBack > 0 {
+		endDate = time.Now()
+		startDate = endDate.Add(-time.Duration(daysBack) * 24 * time.Hour)
+	} else {
+		// Parse das datas
+		if startDateStr == "" {
+			startDate = time.Now().Add(-30 * 24 * time.Hour) // 30 dias atrás por padrão
 		} else {
-			// Parse das datas
-			if startDateStr == "" {
-				startDate = time.Now().Add(-30 * 24 * time.Hour) // 30 dias atrás por padrão
-			} else {
-				startDate, err = parseDate(startDateStr)
-				if err != nil {
-					log.Fatalf("❌ Erro na data de início: %v", err)
-				}
+			startDate, err = parseDateInLocation(startDateStr, calendar.Location)
+			if err != nil {
+				log.Fatalf("❌ Erro na data de início: %v", err)
 			}
+		}
 
-			if endDateStr == "" {
-				endDate = time.Now()
-			} else {
-				endDate, err = parseDate(endDateStr)
-				if err != nil {
-					log.Fatalf("❌ Erro na data de fim: %v", err)
-				}
+		if endDateStr == "" {
+			endDate = time.Now()
+		} else {
+			endDate, err = parseDateInLocation(endDateStr, calendar.Location)
+			if err != nil {
+				log.Fatalf("❌ Erro na data de fim: %v", err)
 			}
 		}
+	}
 
-		// Validação das datas
-		if endDate.Before(startDate) {
-			log.Fatal("❌ Data de fim deve ser posterior à data de início")
-		}
+	// Validação das datas
+	if endDate.Before(startDate) {
+		log.Fatal("❌ Data de fim deve ser posterior à data de início")
+	}
 
-		fmt.Println("🚀 Iniciando PR Champion...")
+	fmt.Fprintln(progress, "🚀 Iniciando PR Champion...")
 
-		prChampion, err := NewPRChampion(token, repositories, startDate, endDate)
-		if err != nil {
-			log.Fatalf("❌ Erro ao inicializar PR Champion: %v", err)
+	// A URL do GitHub também identifica, no modo offline, o host dos repositórios sem host
+	if githubURL == "" {
+		githubURL = os.Getenv("GITHUB_API_URL")
+	}
+
+	var prChampion *PRChampion
+	if offline {
+		fmt.Fprintf(progress, "📴 Modo offline: usando apenas os dados de %s\n", dbPath)
+		prChampion, err = NewOfflinePRChampion(dbPath, githubURL, repositories, startDate, endDate)
+	} else {
+		clientOptions := infrastructure.ClientOptions{API: apiFlag, Token: token, BaseURL: githubURL, App: githubApp}
+		if githubApp != nil {
+			fmt.Fprintf(progress, "🔐 Autenticando como GitHub App %d (instalação %d)\n", githubApp.AppID, githubApp.InstallationID)
 		}
+		prChampion, err = NewPRChampionWithOptions(clientOptions, dbPath, repositories, startDate, endDate)
+	}
+	if err != nil {
+		log.Fatalf("❌ Erro ao inicializar PR Champion: %v", err)
+	}
 
-		// Garante que a conexão seja fechada no final
-		defer func() {
-			if prChampion.cachedClient != nil {
-				prChampion.cachedClient.Close()
-			}
-		}()
+	prChampion.SetScoringConfig(scoringConfig)
+	prChampion.SetScorers(scorers)
+	prChampion.SetTiePolicy(tiePolicy)
+	prChampion.SetWeekCalendar(calendar)
+	prChampion.SetConcurrency(concurrency)
+	prChampion.SetFreshnessPolicy(freshness)
+	prChampion.SetProgressOutput(progress)
+
+	// Garante que a conexão seja fechada no final
+	defer func() {
+		if prChampion.cachedClient != nil {
+			prChampion.cachedClient.Close()
+		}
+	}()
+
+	// Descobre os repositórios da organização, somando-os aos informados explicitamente
+	if orgSpec != "" {
+		org, orgBranches := ParseOrgSpec(orgSpec)
+		err := prChampion.DiscoverRepositories(RepoDiscoveryOptions{
+			Org:                org,
+			ProductionBranches: orgBranches,
+			Topics:             topics,
+			Include:            includePatterns,
+			Exclude:            excludePatterns,
+			SkipArchived:       skipArchived,
+		})
+		if err != nil {
+			log.Fatalf("❌ Erro ao descobrir repositórios de %s: %v", org, err)
+		}
+		if len(prChampion.repositories) == 0 {
+			log.Fatalf("❌ Nenhum repositório de %s passou pelos filtros", org)
+		}
+	}
 
-		// Se a flag clear-database foi especificada, limpa o cache primeiro
-		if clearDatabase {
-			fmt.Println("🗑️  Limpando cache do banco de dados...")
-			if err := prChampion.ClearCache(); err != nil {
-				log.Fatalf("❌ Erro ao limpar cache: %v", err)
-			}
-			fmt.Println("✅ Cache limpo com sucesso!")
+	// Se a flag clear-database foi especificada, limpa o cache primeiro
+	if clearDatabase {
+		fmt.Fprintln(progress, "🗑️  Limpando cache do banco de dados...")
+		if err := prChampion.ClearCache(); err != nil {
+			log.Fatalf("❌ Erro ao limpar cache: %v", err)
 		}
+		fmt.Fprintln(progress, "✅ Cache limpo com sucesso!")
+	}
+
+	if err := prChampion.FetchMergedPRs(); err != nil {
+		log.Fatalf("❌ Erro ao buscar PRs: %v", err)
+	}
 
-		if err := prChampion.FetchMergedPRs(); err != nil {
-			log.Fatalf("❌ Erro ao buscar PRs: %v", err)
+	// Só descarta o relatório anterior depois que a busca terminou
+	if reportFile != nil {
+		if err := reportFile.Truncate(0); err != nil {
+			log.Fatalf("❌ Erro ao escrever arquivo de relatório: %v", err)
 		}
+	}
 
-		prChampion.GenerateReport()
+	if err := prChampion.WriteReport(format, reportOutput); err != nil {
+		log.Fatalf("❌ Erro ao gerar relatório: %v", err)
+	}
 
-		fmt.Println("\n✅ Relatório gerado com sucesso!")
-	},
+	if outPath != "" {
+		fmt.Fprintf(progress, "\n✅ Relatório gerado com sucesso em %s!\n", outPath)
+	} else if format == ReportFormatText {
+		fmt.Fprintln(progress, "\n✅ Relatório gerado com sucesso!")
+	}
 }
 
 func init() {
-	rootCmd.Flags().StringP("token", "t", "", "Token de acesso do GitHub (ou use GITHUB_TOKEN env var)")
-	rootCmd.Flags().StringP("owner", "o", "", "Owner do repositório (compatibilidade com repo único)")
-	rootCmd.Flags().StringP("repo", "r", "", "Nome do repositório (compatibilidade com repo único)")
-	rootCmd.Flags().StringSliceP("repos", "R", []string{}, "Lista de repositórios no formato owner/repo (ou use GITHUB_REPOS env var)")
-	rootCmd.Flags().StringP("start", "s", "", "Data de início (DD/MM/YYYY ou YYYY-MM-DD)")
-	rootCmd.Flags().StringP("end", "e", "", "Data de fim (DD/MM/YYYY ou YYYY-MM-DD)")
-	rootCmd.Flags().IntP("days", "d", 0, "Número de dias atrás para analisar (alternativa às datas específicas)")
-	rootCmd.Flags().BoolP("clear-database", "c", false, "Limpa todo o cache do banco de dados antes de executar")
+	addReportFlags(rootCmd)
+	addReportFlags(reportCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+// addReportFlags registra as flags de análise e relatório no comando informado
+func addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("token", "t", "", "Token de acesso do GitHub (ou use GITHUB_TOKEN env var)")
+	cmd.Flags().StringP("owner", "o", "", "Owner do repositório (compatibilidade com repo único)")
+	cmd.Flags().StringP("repo", "r", "", "Nome do repositório (compatibilidade com repo único)")
+	cmd.Flags().StringSliceP("repos", "R", []string{}, "Lista de repositórios no formato owner/repo (ou use GITHUB_REPOS env var)")
+	cmd.Flags().StringP("start", "s", "", "Data de início (DD/MM/YYYY ou YYYY-MM-DD)")
+	cmd.Flags().StringP("end", "e", "", "Data de fim (DD/MM/YYYY ou YYYY-MM-DD)")
+	cmd.Flags().IntP("days", "d", 0, "Número de dias atrás para analisar (alternativa às datas específicas)")
+	cmd.Flags().BoolP("clear-database", "c", false, "Limpa todo o cache do banco de dados antes de executar")
+	cmd.Flags().StringP("format", "f", ReportFormatText, "Formato do relatório: text, json, markdown, html ou csv")
+	cmd.Flags().String("out", "", "Arquivo de saída do relatório (padrão: saída padrão)")
+	cmd.Flags().String("scoring-config", "", "Arquivo YAML ou JSON com os pesos de pontuação")
+	cmd.Flags().StringSlice("scorer", DefaultScorers, "Rankings exibidos no relatório ("+strings.Join(ScorerNames(), ", ")+")")
+	cmd.Flags().String("timezone", "UTC", "Fuso horário IANA usado para definir as semanas (ex: America/Sao_Paulo)")
+	cmd.Flags().String("week-start", "monday", "Dia de início da semana: monday ou sunday")
+	cmd.Flags().String("period", string(PeriodWeek), "Período de apuração dos campeões: day, week, month, quarter ou sprint:14d@2026-01-05")
+	cmd.Flags().Int("concurrency", DefaultConcurrency, "Número de PRs com comentários e reações buscados em paralelo")
+	cmd.Flags().String("tie-policy", string(DefaultTiePolicy), "Desempate dos campeões semanais: shared, secondary ou alphabetical")
+	cmd.Flags().String("api", infrastructure.APIREST, "API do GitHub usada na busca: rest ou graphql (menos chamadas para comentários e reações)")
+	cmd.Flags().String("github-url", "", "URL do GitHub Enterprise Server, ex: https://ghe.corp.com (ou use GITHUB_API_URL env var)")
+	cmd.Flags().String("org", "", "Organização cujos repositórios são analisados (ex: minha-org ou minha-org:main|master)")
+	cmd.Flags().StringSlice("topic", []string{}, "Com --org, analisa apenas repositórios com todos os tópicos informados")
+	cmd.Flags().StringSlice("include", []string{}, "Com --org, padrões glob de nomes de repositórios incluídos (ex: 'svc-*')")
+	cmd.Flags().StringSlice("exclude", []string{}, "Com --org, padrões glob de nomes de repositórios ignorados (ex: '*-archive')")
+	cmd.Flags().Bool("skip-archived", false, "Com --org, ignora repositórios arquivados")
+	cmd.Flags().Int64("app-id", 0, "ID da GitHub App usada na autenticação (ou use GITHUB_APP_ID env var)")
+	cmd.Flags().Int64("app-installation-id", 0, "ID da instalação da GitHub App (ou use GITHUB_APP_INSTALLATION_ID env var)")
+	cmd.Flags().String("app-private-key", "", "Arquivo PEM com a chave privada da GitHub App (ou use GITHUB_APP_PRIVATE_KEY_PATH env var)")
+	cmd.Flags().String("cache-ttl", "7d", "Validade do cache de comentários e reações (ex: 7d, 2w ou 12h)")
+	cmd.Flags().String("freeze-after-merge", "", "Congela comentários e reações salvos depois de merge + N (ex: 3d); vazio = desativado")
+	cmd.Flags().Bool("refresh-current-week", false, "Sempre busca na API comentários e reações de PRs mergeados na semana atual")
+	cmd.Flags().Bool("offline", false, "Gera o relatório apenas com os dados já sincronizados no banco local (sem token e sem API)")
+	cmd.Flags().String("database", defaultDatabasePath, "Banco SQLite usado como cache e, com --offline, como fonte dos dados")
 }
 
 func main() {
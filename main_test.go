@@ -1,14 +1,16 @@
 package main
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-github/v70/github"
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
 )
 
-func TestGetTopUsersByComments(t *testing.T) {
+func TestLeaderboardByComments(t *testing.T) {
 	// Cria instância do PRChampion com dados de teste
 	pc := &PRChampion{
 		userStats: make(map[string]*UserStats),
@@ -61,85 +63,66 @@ func TestGetTopUsersByComments(t *testing.T) {
 	}
 
 	// Testa o ranking por comentários (número total)
-	topComments := pc.getTopUsersByComments(3)
+	topComments := pc.Leaderboard(commentCountScorer{}, 3)
 
 	if len(topComments) != 3 {
-		t.Errorf("Expected 3 users with comments, got %d", len(topComments))
+		t.Fatalf("Expected 3 users with comments, got %d", len(topComments))
 	}
 
 	// Verifica se estão ordenados corretamente por número de comentários
-	if topComments[0].Username != "user2" || topComments[0].CommentsCount != 15 {
-		t.Errorf("Expected user2 with 15 comments at position 0, got %s with %d comments",
-			topComments[0].Username, topComments[0].CommentsCount)
+	if topComments[0].Username != "user2" || topComments[0].Score != 15 {
+		t.Errorf("Expected user2 with 15 comments at position 0, got %s with %v comments",
+			topComments[0].Username, topComments[0].Score)
 	}
 
-	if topComments[1].Username != "user1" || topComments[1].CommentsCount != 10 {
-		t.Errorf("Expected user1 with 10 comments at position 1, got %s with %d comments",
-			topComments[1].Username, topComments[1].CommentsCount)
+	if topComments[1].Username != "user1" || topComments[1].Score != 10 {
+		t.Errorf("Expected user1 with 10 comments at position 1, got %s with %v comments",
+			topComments[1].Username, topComments[1].Score)
 	}
 
-	if topComments[2].Username != "user3" || topComments[2].CommentsCount != 5 {
-		t.Errorf("Expected user3 with 5 comments at position 2, got %s with %d comments",
-			topComments[2].Username, topComments[2].CommentsCount)
-	}
-
-	// Testa o ranking por pontuação de comentários
-	topCommentScore := pc.getTopUsersByCommentScore(3)
-
-	if len(topCommentScore) != 2 { // Apenas user1 e user2 têm pontos
-		t.Errorf("Expected 2 users with comment score, got %d", len(topCommentScore))
-	}
-
-	// Verifica se estão ordenados corretamente por pontuação
-	if topCommentScore[0].Username != "user2" || topCommentScore[0].CommentScore != 2 {
-		t.Errorf("Expected user2 with 2 points at position 0, got %s with %d points",
-			topCommentScore[0].Username, topCommentScore[0].CommentScore)
-	}
-
-	if topCommentScore[1].Username != "user1" || topCommentScore[1].CommentScore != 1 {
-		t.Errorf("Expected user1 with 1 point at position 1, got %s with %d points",
-			topCommentScore[1].Username, topCommentScore[1].CommentScore)
+	if topComments[2].Username != "user3" || topComments[2].Score != 5 {
+		t.Errorf("Expected user3 with 5 comments at position 2, got %s with %v comments",
+			topComments[2].Username, topComments[2].Score)
 	}
 
 	// Testa o ranking por pontuação ponderada de comentários
-	topWeightedScore := pc.getTopUsersByWeightedCommentScore(3)
+	topWeightedScore := pc.Leaderboard(weightedCommentScorer{}, 3)
 
 	if len(topWeightedScore) != 3 { // Todos têm pontuação > 0
-		t.Errorf("Expected 3 users with weighted comment score, got %d", len(topWeightedScore))
+		t.Fatalf("Expected 3 users with weighted comment score, got %d", len(topWeightedScore))
 	}
 
 	// Verifica se estão ordenados corretamente por pontuação ponderada
-	if topWeightedScore[0].Username != "user2" || topWeightedScore[0].WeightedCommentScore != 22.0 {
+	if topWeightedScore[0].Username != "user2" || topWeightedScore[0].Score != 22.0 {
 		t.Errorf("Expected user2 with 22.0 weighted points at position 0, got %s with %.1f points",
-			topWeightedScore[0].Username, topWeightedScore[0].WeightedCommentScore)
+			topWeightedScore[0].Username, topWeightedScore[0].Score)
 	}
 
-	if topWeightedScore[1].Username != "user1" || topWeightedScore[1].WeightedCommentScore != 12.5 {
+	if topWeightedScore[1].Username != "user1" || topWeightedScore[1].Score != 12.5 {
 		t.Errorf("Expected user1 with 12.5 weighted points at position 1, got %s with %.1f points",
-			topWeightedScore[1].Username, topWeightedScore[1].WeightedCommentScore)
+			topWeightedScore[1].Username, topWeightedScore[1].Score)
 	}
 
-	if topWeightedScore[2].Username != "user3" || topWeightedScore[2].WeightedCommentScore != 3.5 {
+	if topWeightedScore[2].Username != "user3" || topWeightedScore[2].Score != 3.5 {
 		t.Errorf("Expected user3 with 3.5 weighted points at position 2, got %s with %.1f points",
-			topWeightedScore[2].Username, topWeightedScore[2].WeightedCommentScore)
+			topWeightedScore[2].Username, topWeightedScore[2].Score)
 	}
 
-	// Testa o ranking por pontuação semanal de qualidade de comentários
-	topWeeklyQuality := pc.getTopUsersByWeightedCommentWeeklyScore(3)
+	// O ranking semanal por qualidade vem das estatísticas consolidadas, não de PRs e comentários
+	topWeeklyQuality := pc.Leaderboard(weeklyQualityScorer{}, 3)
 
 	if len(topWeeklyQuality) != 2 { // Apenas user1 e user2 têm pontuação semanal > 0
-		t.Errorf("Expected 2 users with weekly quality score, got %d", len(topWeeklyQuality))
+		t.Fatalf("Expected 2 users with weekly quality score, got %d", len(topWeeklyQuality))
 	}
 
-	// Verifica se estão ordenados corretamente por pontuação semanal
-	if topWeeklyQuality[0].Username != "user2" || topWeeklyQuality[0].WeightedCommentWeeklyScore != 2 {
-		t.Errorf("Expected user2 with 2 weekly quality points at position 0, got %s with %d points",
-			topWeeklyQuality[0].Username, topWeeklyQuality[0].WeightedCommentWeeklyScore)
+	if topWeeklyQuality[0].Username != "user2" || topWeeklyQuality[0].Score != 2 {
+		t.Errorf("Expected user2 with 2 weekly quality points at position 0, got %s with %v points",
+			topWeeklyQuality[0].Username, topWeeklyQuality[0].Score)
 	}
 
-	if topWeeklyQuality[1].Username != "user1" || topWeeklyQuality[1].WeightedCommentWeeklyScore != 1 {
-		t.Errorf("Expected user1 with 1 weekly quality point at position 1, got %s with %d points",
-			topWeeklyQuality[1].Username, topWeeklyQuality[1].WeightedCommentWeeklyScore)
+	if topWeeklyQuality[1].Username != "user1" || topWeeklyQuality[1].Score != 1 {
+		t.Errorf("Expected user1 with 1 weekly quality point at position 1, got %s with %v points",
+			topWeeklyQuality[1].Username, topWeeklyQuality[1].Score)
 	}
 }
 
@@ -441,7 +424,7 @@ func TestUserStatsCalculation(t *testing.T) {
 	}
 }
 
-func TestGetTopUsersByScore(t *testing.T) {
+func TestLeaderboardByScore(t *testing.T) {
 	pc := &PRChampion{
 		userStats: map[string]*UserStats{
 			"user1": {Username: "user1", TotalScore: 3, PRsCount: 15},
@@ -451,11 +434,11 @@ func TestGetTopUsersByScore(t *testing.T) {
 		},
 	}
 
-	topUsers := pc.getTopUsersByScore(3)
+	topUsers := pc.Leaderboard(weeklyScoreScorer{}, 3)
 
 	// Verificar ordenação
 	if len(topUsers) != 3 {
-		t.Errorf("Expected 3 users, got %d", len(topUsers))
+		t.Fatalf("Expected 3 users, got %d", len(topUsers))
 	}
 
 	if topUsers[0].Username != "user1" {
@@ -556,6 +539,93 @@ func TestParseRepositories(t *testing.T) {
 	}
 }
 
+func TestParseRepositoriesWithHost(t *testing.T) {
+	repositories, err := parseRepositories([]string{"GHE.corp.com/org/billing:main|release/v2", "org/api:main"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Repository{
+		{Host: "ghe.corp.com", Owner: "org", Name: "billing", ProductionBranches: []string{"main", "release/v2"}},
+		{Owner: "org", Name: "api", ProductionBranches: []string{"main"}},
+	}
+	if !reflect.DeepEqual(repositories, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, repositories)
+	}
+}
+
+func TestCheckRepositoryHosts(t *testing.T) {
+	tests := []struct {
+		repos       []string
+		defaultHost string
+		hasError    bool
+	}{
+		{[]string{"ghe.corp.com/org/api:main", "Org/API:main"}, "", true},
+		// O mesmo repositório repetido no mesmo host continua aceito
+		{[]string{"ghe.corp.com/org/api:main", "GHE.corp.com/org/api:release"}, "", false},
+		// Sem host, o repositório é do host padrão da execução
+		{[]string{"github.com/org/api:main", "org/api:release"}, "", false},
+		{[]string{"github.com/org/api:main", "org/api:release"}, "https://ghe.corp.com", true},
+		{[]string{"ghe.corp.com/org/api:main", "org/api:release"}, "https://ghe.corp.com/api/v3/", false},
+	}
+
+	for _, tt := range tests {
+		repositories, err := parseRepositories(tt.repos)
+		if err != nil {
+			t.Fatalf("parseRepositories(%v) error = %v", tt.repos, err)
+		}
+		err = checkRepositoryHosts(repositories, tt.defaultHost)
+		if (err != nil) != tt.hasError {
+			t.Errorf("checkRepositoryHosts(%v, %q) error = %v, expected error: %v", tt.repos, tt.defaultHost, err, tt.hasError)
+		}
+	}
+}
+
+func TestHostClientOptions(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN_GHE_CORP_COM", "ghe-token")
+	t.Setenv("GITHUB_TOKEN_GITHUB_COM", "")
+	defaults := infrastructure.ClientOptions{API: infrastructure.APIGraphQL, Token: "default-token", BaseURL: "https://other.corp.com"}
+
+	defaults.App = &infrastructure.GithubAppCredentials{AppID: 1, InstallationID: 2}
+
+	opts, err := hostClientOptions(defaults, "ghe.corp.com")
+	if err != nil || opts.BaseURL != "ghe.corp.com" || opts.Token != "ghe-token" || opts.App != nil || opts.API != infrastructure.APIGraphQL {
+		t.Errorf("Unexpected options for ghe.corp.com: %+v (%v)", opts, err)
+	}
+
+	// Sem token específico, as credenciais padrão não são reaproveitadas em outro host
+	if _, err := hostClientOptions(defaults, "github.com"); err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN_GITHUB_COM") {
+		t.Errorf("Expected an error asking for GITHUB_TOKEN_GITHUB_COM, got %v", err)
+	}
+
+	t.Setenv("GITHUB_TOKEN_GITHUB_COM", "public-token")
+	opts, err = hostClientOptions(defaults, "github.com")
+	if err != nil || opts.BaseURL != "" || opts.Token != "public-token" || opts.App != nil {
+		t.Errorf("Expected github.com to use the public API with its own token, got %+v (%v)", opts, err)
+	}
+}
+
+func TestResolveGithubApp(t *testing.T) {
+	t.Setenv("GITHUB_APP_ID", "")
+	t.Setenv("GITHUB_APP_INSTALLATION_ID", "")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY_PATH", "")
+
+	app, err := resolveGithubApp(0, 0, "")
+	if err != nil || app != nil {
+		t.Errorf("Expected no GitHub App without configuration, got %v (err = %v)", app, err)
+	}
+
+	t.Setenv("GITHUB_APP_ID", "123")
+	if _, err := resolveGithubApp(0, 42, ""); err == nil {
+		t.Error("Expected error when the private key is missing")
+	}
+
+	t.Setenv("GITHUB_APP_ID", "abc")
+	if _, err := resolveGithubApp(0, 42, "app.pem"); err == nil {
+		t.Error("Expected error for invalid GITHUB_APP_ID")
+	}
+}
+
 func TestEnvironmentVariableRepos(t *testing.T) {
 	// Teste simula a lógica de parsing de repositórios da variável de ambiente
 	envReposString := "microsoft/vscode,facebook/react, golang/go "
@@ -591,3 +661,142 @@ func TestEnvironmentVariableRepos(t *testing.T) {
 		}
 	}
 }
+
+// newTestPR cria um PR mergeado para os testes
+func newTestPR(owner, repo, user string, number int, mergedAt time.Time) *github.PullRequest {
+	return &github.PullRequest{
+		Number:   github.Int(number),
+		User:     &github.User{Login: github.String(user)},
+		MergedAt: &github.Timestamp{Time: mergedAt},
+		Base: &github.PullRequestBranch{
+			Ref: github.String("main"),
+			Repo: &github.Repository{
+				Name:  github.String(repo),
+				Owner: &github.User{Login: github.String(owner)},
+			},
+		},
+	}
+}
+
+func TestProcessWeeklyDataRepoStats(t *testing.T) {
+	monday, _ := time.Parse("2006-01-02 15:04:05", "2024-09-30 10:00:00")
+
+	pc := &PRChampion{
+		userStats: make(map[string]*UserStats),
+		repositories: []Repository{
+			{Owner: "org", Name: "api"},
+			{Owner: "org", Name: "web"},
+		},
+	}
+
+	prs := []*github.PullRequest{
+		newTestPR("org", "api", "alice", 1, monday),
+		newTestPR("org", "api", "alice", 2, monday.Add(24*time.Hour)),
+		newTestPR("org", "api", "bob", 3, monday.Add(48*time.Hour)),
+		newTestPR("org", "web", "bob", 4, monday.Add(72*time.Hour)),
+		newTestPR("org", "web", "bob", 5, monday.Add(8*24*time.Hour)),
+	}
+
+	pc.processWeeklyData(prs)
+	pc.calculateUserStats()
+
+	if len(pc.weeklyData) != 2 {
+		t.Fatalf("Expected 2 weeks, got %d", len(pc.weeklyData))
+	}
+
+	firstWeek := pc.weeklyData[0]
+	if firstWeek.RepoData["org/api"]["alice"] != 2 || firstWeek.RepoData["org/web"]["bob"] != 1 {
+		t.Errorf("Unexpected repo data for first week: %v", firstWeek.RepoData)
+	}
+	if firstOrEmpty(firstWeek.RepoWinners["org/api"]) != "alice" || firstOrEmpty(firstWeek.RepoWinners["org/web"]) != "bob" {
+		t.Errorf("Unexpected repo winners for first week: %v", firstWeek.RepoWinners)
+	}
+
+	bob := pc.userStats["bob"]
+	if bob.RepoStats["org/api"] != 1 || bob.RepoStats["org/web"] != 2 {
+		t.Errorf("Unexpected repo stats for bob: %v", bob.RepoStats)
+	}
+
+	topWeb := pc.getTopUsersForRepo("org/web", 3)
+	if len(topWeb) != 1 || topWeb[0].Username != "bob" || topWeb[0].PRsCount != 2 {
+		t.Errorf("Unexpected top users for org/web: %v", topWeb)
+	}
+
+	if names := pc.getRepositoryNames(); len(names) != 2 || names[0] != "org/api" || names[1] != "org/web" {
+		t.Errorf("Unexpected repository names: %v", names)
+	}
+}
+
+func TestWeekCalendarTimezone(t *testing.T) {
+	calendar, err := NewWeekCalendar("America/Sao_Paulo", "monday")
+	if err != nil {
+		t.Fatalf("NewWeekCalendar() error = %v", err)
+	}
+
+	// Domingo 22:30 em São Paulo já é segunda-feira em UTC
+	sundayNight := time.Date(2024, 10, 7, 1, 30, 0, 0, time.UTC)
+
+	if got := getWeekStart(sundayNight).Format("2006-01-02"); got != "2024-10-07" {
+		t.Errorf("UTC week start = %s, want 2024-10-07", got)
+	}
+
+	weekStart := calendar.WeekStartOf(sundayNight)
+	if got := weekStart.Format("2006-01-02"); got != "2024-09-30" {
+		t.Errorf("America/Sao_Paulo week start = %s, want 2024-09-30", got)
+	}
+	if weekStart.Hour() != 0 || weekStart.Location() != calendar.Location {
+		t.Errorf("Expected local midnight, got %s", weekStart)
+	}
+	if got := calendar.WeekEndOf(weekStart).Format("2006-01-02"); got != "2024-10-06" {
+		t.Errorf("Week end = %s, want 2024-10-06", got)
+	}
+}
+
+func TestWeekCalendarSundayStart(t *testing.T) {
+	calendar, err := NewWeekCalendar("", "sunday")
+	if err != nil {
+		t.Fatalf("NewWeekCalendar() error = %v", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2024-10-06 09:00:00", "2024-10-06"}, // Domingo -> Mesmo domingo
+		{"2024-10-05 23:00:00", "2024-09-29"}, // Sábado -> Domingo anterior
+		{"2024-09-30 10:00:00", "2024-09-29"}, // Segunda -> Domingo anterior
+	}
+
+	for _, test := range tests {
+		inputTime, _ := time.Parse("2006-01-02 15:04:05", test.input)
+		if got := calendar.WeekStartOf(inputTime).Format("2006-01-02"); got != test.expected {
+			t.Errorf("For input %s, expected %s, got %s", test.input, test.expected, got)
+		}
+	}
+
+	if _, err := NewWeekCalendar("Mars/Olympus", ""); err == nil {
+		t.Error("Expected error for invalid timezone")
+	}
+	if _, err := NewWeekCalendar("", "friday"); err == nil {
+		t.Error("Expected error for invalid week start")
+	}
+}
+
+func TestProcessWeeklyDataHonorsTimezone(t *testing.T) {
+	calendar, _ := NewWeekCalendar("America/Sao_Paulo", "monday")
+
+	pc := &PRChampion{userStats: make(map[string]*UserStats)}
+	pc.SetWeekCalendar(calendar)
+
+	// Domingo à noite em São Paulo (segunda-feira em UTC)
+	pc.processWeeklyData([]*github.PullRequest{
+		newTestPR("org", "api", "alice", 1, time.Date(2024, 10, 7, 1, 30, 0, 0, time.UTC)),
+	})
+
+	if len(pc.weeklyData) != 1 {
+		t.Fatalf("Expected 1 week, got %d", len(pc.weeklyData))
+	}
+	if got := pc.weeklyData[0].StartDate.Format("2006-01-02"); got != "2024-09-30" {
+		t.Errorf("Week start = %s, want 2024-09-30", got)
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PeriodKind identifica a granularidade usada para agrupar PRs e comentários
+type PeriodKind string
+
+const (
+	PeriodDay     PeriodKind = "day"
+	PeriodWeek    PeriodKind = "week"
+	PeriodSprint  PeriodKind = "sprint"
+	PeriodMonth   PeriodKind = "month"
+	PeriodQuarter PeriodKind = "quarter"
+)
+
+// PeriodSpec descreve o período de apuração dos campeões.
+// Para sprints, Days é a duração em dias e Anchor o primeiro dia de uma sprint conhecida.
+type PeriodSpec struct {
+	Kind   PeriodKind
+	Days   int
+	Anchor time.Time
+}
+
+// DefaultPeriod é o período usado quando --period não é informado
+var DefaultPeriod = PeriodSpec{Kind: PeriodWeek}
+
+// ParsePeriod interpreta o valor de --period: day, week, month, quarter ou sprint:<duração>@<data>.
+// A duração da sprint aceita dias (14d) ou semanas (2w); a data âncora é interpretada no fuso informado.
+func ParsePeriod(value string, location *time.Location) (PeriodSpec, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch PeriodKind(value) {
+	case "":
+		return DefaultPeriod, nil
+	case PeriodDay, PeriodWeek, PeriodMonth, PeriodQuarter:
+		return PeriodSpec{Kind: PeriodKind(value)}, nil
+	}
+
+	if !strings.HasPrefix(value, "sprint:") {
+		return PeriodSpec{}, fmt.Errorf("período inválido: %s (use day, week, month, quarter ou sprint:14d@2026-01-05)", value)
+	}
+
+	durationStr, anchorStr, found := strings.Cut(strings.TrimPrefix(value, "sprint:"), "@")
+	if !found || anchorStr == "" {
+		return PeriodSpec{}, fmt.Errorf("período de sprint inválido: %s (informe a data âncora, ex: sprint:14d@2026-01-05)", value)
+	}
+
+	days, err := parseDayDuration(durationStr)
+	if err != nil {
+		return PeriodSpec{}, fmt.Errorf("período de sprint inválido: %v", err)
+	}
+
+	anchor, err := parseDateInLocation(anchorStr, location)
+	if err != nil {
+		return PeriodSpec{}, fmt.Errorf("período de sprint inválido: %v", err)
+	}
+
+	return PeriodSpec{Kind: PeriodSprint, Days: days, Anchor: anchor}, nil
+}
+
+// parseDayDuration converte durações como "14d" ou "2w" para dias
+func parseDayDuration(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return 0, fmt.Errorf("duração inválida: %q (use, por exemplo, 14d ou 2w)", value)
+	}
+
+	multiplier := 0
+	switch value[len(value)-1] {
+	case 'd':
+		multiplier = 1
+	case 'w':
+		multiplier = 7
+	default:
+		return 0, fmt.Errorf("duração inválida: %q (use, por exemplo, 14d ou 2w)", value)
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("duração inválida: %q (use, por exemplo, 14d ou 2w)", value)
+	}
+
+	return n * multiplier, nil
+}
+
+// String retorna a representação do período aceita por --period
+func (p PeriodSpec) String() string {
+	if p.Kind == PeriodSprint {
+		return fmt.Sprintf("sprint:%dd@%s", p.Days, p.Anchor.Format("2006-01-02"))
+	}
+	if p.Kind == "" {
+		return string(PeriodWeek)
+	}
+	return string(p.Kind)
+}
+
+// Label retorna o nome do período usado nas linhas do relatório
+func (p PeriodSpec) Label() string {
+	switch p.Kind {
+	case PeriodDay:
+		return "Dia"
+	case PeriodSprint:
+		return "Sprint"
+	case PeriodMonth:
+		return "Mês"
+	case PeriodQuarter:
+		return "Trimestre"
+	default:
+		return "Semana"
+	}
+}
+
+// SummaryTitle retorna o título da seção de resumo por período
+func (p PeriodSpec) SummaryTitle() string {
+	switch p.Kind {
+	case PeriodDay:
+		return "Resumo Diário"
+	case PeriodSprint:
+		return "Resumo por Sprint"
+	case PeriodMonth:
+		return "Resumo Mensal"
+	case PeriodQuarter:
+		return "Resumo Trimestral"
+	default:
+		return "Resumo Semanal"
+	}
+}
+
+// PeriodStartOf retorna a meia-noite local do primeiro dia do período que contém t
+func (c WeekCalendar) PeriodStartOf(t time.Time) time.Time {
+	local := t.In(c.location())
+	year, month, day := local.Date()
+
+	switch c.Period.Kind {
+	case PeriodDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, c.location())
+	case PeriodMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, c.location())
+	case PeriodQuarter:
+		firstMonth := time.Month((int(month)-1)/3*3 + 1)
+		return time.Date(year, firstMonth, 1, 0, 0, 0, 0, c.location())
+	case PeriodSprint:
+		if c.Period.Days <= 0 {
+			return c.WeekStartOf(t)
+		}
+		anchor := c.Period.Anchor.In(c.location())
+		anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, c.location())
+
+		// Diferença em dias de calendário (calculada em UTC para não sofrer com horário de verão)
+		anchorDay := time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, time.UTC)
+		currentDay := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		days := int(currentDay.Sub(anchorDay).Hours() / 24)
+
+		index := days / c.Period.Days
+		if days < 0 && days%c.Period.Days != 0 {
+			index-- // Divisão com arredondamento para baixo para datas anteriores à âncora
+		}
+		return anchor.AddDate(0, 0, index*c.Period.Days)
+	default:
+		return c.WeekStartOf(t)
+	}
+}
+
+// PeriodEndOf retorna o último dia do período iniciado em periodStart
+func (c WeekCalendar) PeriodEndOf(periodStart time.Time) time.Time {
+	switch c.Period.Kind {
+	case PeriodDay:
+		return periodStart
+	case PeriodMonth:
+		return periodStart.AddDate(0, 1, -1)
+	case PeriodQuarter:
+		return periodStart.AddDate(0, 3, -1)
+	case PeriodSprint:
+		if c.Period.Days <= 0 {
+			return c.WeekEndOf(periodStart)
+		}
+		return periodStart.AddDate(0, 0, c.Period.Days-1)
+	default:
+		return c.WeekEndOf(periodStart)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"", "week", false},
+		{"week", "week", false},
+		{"Day", "day", false},
+		{"month", "month", false},
+		{"quarter", "quarter", false},
+		{"sprint:14d@2026-01-05", "sprint:14d@2026-01-05", false},
+		{"sprint:2w@05/01/2026", "sprint:14d@2026-01-05", false},
+		{"sprint:14d", "", true},
+		{"sprint:0d@2026-01-05", "", true},
+		{"sprint:14x@2026-01-05", "", true},
+		{"fortnight", "", true},
+	}
+
+	for _, tt := range tests {
+		period, err := ParsePeriod(tt.input, time.UTC)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePeriod(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if err == nil && period.String() != tt.expected {
+			t.Errorf("ParsePeriod(%q) = %s, want %s", tt.input, period, tt.expected)
+		}
+	}
+}
+
+func TestPeriodBoundaries(t *testing.T) {
+	sprint, _ := ParsePeriod("sprint:14d@2026-01-05", time.UTC)
+
+	tests := []struct {
+		name          string
+		period        PeriodSpec
+		input         string
+		expectedStart string
+		expectedEnd   string
+	}{
+		{"day", PeriodSpec{Kind: PeriodDay}, "2026-01-07 15:00:00", "2026-01-07", "2026-01-07"},
+		{"week", PeriodSpec{Kind: PeriodWeek}, "2026-01-07 15:00:00", "2026-01-05", "2026-01-11"},
+		{"month", PeriodSpec{Kind: PeriodMonth}, "2026-02-17 15:00:00", "2026-02-01", "2026-02-28"},
+		{"quarter", PeriodSpec{Kind: PeriodQuarter}, "2026-05-17 15:00:00", "2026-04-01", "2026-06-30"},
+		{"sprint anchor day", sprint, "2026-01-05 00:00:00", "2026-01-05", "2026-01-18"},
+		{"sprint second", sprint, "2026-01-20 10:00:00", "2026-01-19", "2026-02-01"},
+		{"sprint before anchor", sprint, "2026-01-04 10:00:00", "2025-12-22", "2026-01-04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := DefaultWeekCalendar()
+			calendar.Period = tt.period
+
+			input, _ := time.Parse("2006-01-02 15:04:05", tt.input)
+			start := calendar.PeriodStartOf(input)
+			if got := start.Format("2006-01-02"); got != tt.expectedStart {
+				t.Errorf("PeriodStartOf(%s) = %s, want %s", tt.input, got, tt.expectedStart)
+			}
+			if got := calendar.PeriodEndOf(start).Format("2006-01-02"); got != tt.expectedEnd {
+				t.Errorf("PeriodEndOf(%s) = %s, want %s", tt.expectedStart, got, tt.expectedEnd)
+			}
+		})
+	}
+}
+
+func TestProcessWeeklyDataBySprint(t *testing.T) {
+	calendar := DefaultWeekCalendar()
+	calendar.Period, _ = ParsePeriod("sprint:14d@2026-01-05", time.UTC)
+
+	pc := &PRChampion{userStats: make(map[string]*UserStats)}
+	pc.SetWeekCalendar(calendar)
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 12, 0, 0, 0, time.UTC) }
+	pc.processWeeklyData([]*github.PullRequest{
+		newTestPR("org", "api", "alice", 1, day(5)),
+		newTestPR("org", "api", "bob", 2, day(12)), // mesma sprint, semana diferente
+		newTestPR("org", "api", "bob", 3, day(16)),
+		newTestPR("org", "api", "alice", 4, day(20)), // segunda sprint
+	})
+	pc.calculateUserStats()
+
+	if len(pc.weeklyData) != 2 {
+		t.Fatalf("Expected 2 sprints, got %d", len(pc.weeklyData))
+	}
+	if pc.weeklyData[0].Winner != "bob" || pc.weeklyData[1].Winner != "alice" {
+		t.Errorf("Unexpected sprint winners: %s, %s", pc.weeklyData[0].Winner, pc.weeklyData[1].Winner)
+	}
+	if got := pc.weeklyData[0].EndDate.Format("2006-01-02"); got != "2026-01-18" {
+		t.Errorf("First sprint end = %s, want 2026-01-18", got)
+	}
+	if pc.userStats["bob"].TotalScore != 1 || pc.userStats["alice"].TotalScore != 1 {
+		t.Errorf("Expected one point per sprint champion, got bob=%d alice=%d",
+			pc.userStats["bob"].TotalScore, pc.userStats["alice"].TotalScore)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// progressReporter imprime o andamento de uma etapa longa com estimativa de término (ETA)
+// e o rate limit restante da API. É seguro para uso concorrente.
+type progressReporter struct {
+	mu        sync.Mutex
+	out       io.Writer
+	label     string
+	total     int
+	done      int
+	step      int // imprime a cada step itens concluídos
+	started   time.Time
+	rateLimit func() (infrastructure.RateLimitStatus, bool)
+	now       func() time.Time
+}
+
+// newProgressReporter cria um reporter para total itens que escreve em out; rateLimit pode ser nil
+func newProgressReporter(out io.Writer, label string, total int, rateLimit func() (infrastructure.RateLimitStatus, bool)) *progressReporter {
+	step := total / 20 // No máximo ~20 linhas de progresso por etapa
+	if step < 1 {
+		step = 1
+	}
+	return &progressReporter{
+		out:       out,
+		label:     label,
+		total:     total,
+		step:      step,
+		started:   time.Now(),
+		rateLimit: rateLimit,
+		now:       time.Now,
+	}
+}
+
+// Done registra a conclusão de um item e imprime o progresso quando apropriado
+func (p *progressReporter) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	if p.done%p.step != 0 && p.done != p.total {
+		return
+	}
+	fmt.Fprintln(p.out, p.line())
+}
+
+// line monta a linha de progresso; deve ser chamada com o mutex travado
+func (p *progressReporter) line() string {
+	percent := 100
+	if p.total > 0 {
+		percent = p.done * 100 / p.total
+	}
+
+	line := fmt.Sprintf("  ⏱️  %s: %d/%d (%d%%)", p.label, p.done, p.total, percent)
+
+	elapsed := p.now().Sub(p.started)
+	if p.done > 0 && p.done < p.total {
+		remaining := time.Duration(float64(elapsed) / float64(p.done) * float64(p.total-p.done))
+		line += fmt.Sprintf(" - ETA %s", remaining.Round(time.Second))
+	} else if p.done == p.total {
+		line += fmt.Sprintf(" - concluído em %s", elapsed.Round(time.Second))
+	}
+
+	if p.rateLimit != nil {
+		if status, ok := p.rateLimit(); ok && status.Known {
+			line += fmt.Sprintf(" - rate limit %d/%d", status.Remaining, status.Limit)
+		}
+	}
+
+	return line
+}
+
+// SetProgressOutput define onde as mensagens de progresso da análise são escritas, inclusive as do
+// cliente da API. Com relatórios estruturados na saída padrão, as mensagens vão para stderr
+func (pc *PRChampion) SetProgressOutput(w io.Writer) {
+	pc.progressOut = w
+	if configurable, ok := pc.client.(infrastructure.ProgressConfigurable); ok {
+		configurable.SetProgressOutput(w)
+	}
+}
+
+// progress retorna a saída das mensagens de progresso (padrão: saída padrão)
+func (pc *PRChampion) progress() io.Writer {
+	if pc.progressOut == nil {
+		return os.Stdout
+	}
+	return pc.progressOut
+}
+
+// rateLimitStatus retorna o rate limit conhecido do cliente, quando ele o acompanha
+func (pc *PRChampion) rateLimitStatus() (infrastructure.RateLimitStatus, bool) {
+	reporter, ok := pc.client.(infrastructure.RateLimitReporter)
+	if !ok {
+		return infrastructure.RateLimitStatus{}, false
+	}
+	return reporter.RateLimit(), true
+}
+
+// recordFetchError registra dados que não puderam ser buscados; o relatório passa a ser incompleto
+func (pc *PRChampion) recordFetchError(err error) {
+	pc.fetchErrors = append(pc.fetchErrors, err.Error())
+}
+
+// Incomplete indica se algum dado não pôde ser buscado durante a análise
+func (pc *PRChampion) Incomplete() bool {
+	return len(pc.fetchErrors) > 0
+}
+
+// FetchErrors retorna as falhas de busca registradas durante a análise
+func (pc *PRChampion) FetchErrors() []string {
+	return append([]string(nil), pc.fetchErrors...)
+}
+
+// maxReportedFetchErrors é o número máximo de falhas listadas nos relatórios
+const maxReportedFetchErrors = 10
+
+// limitFetchErrors limita a lista de falhas exibida, resumindo as excedentes
+func limitFetchErrors(messages []string, limit int) []string {
+	if len(messages) <= limit {
+		return messages
+	}
+	limited := append([]string(nil), messages[:limit]...)
+	return append(limited, fmt.Sprintf("... e mais %d falha(s)", len(messages)-limit))
+}
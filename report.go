@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Formatos de relatório suportados
+const (
+	ReportFormatText     = "text"
+	ReportFormatJSON     = "json"
+	ReportFormatMarkdown = "markdown"
+	ReportFormatHTML     = "html"
+	ReportFormatCSV      = "csv"
+)
+
+// supportedReportFormats lista os formatos aceitos pela flag --format
+var supportedReportFormats = []string{
+	ReportFormatText,
+	ReportFormatJSON,
+	ReportFormatMarkdown,
+	ReportFormatHTML,
+	ReportFormatCSV,
+}
+
+// normalizeReportFormat valida e normaliza o formato de relatório informado
+func normalizeReportFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return ReportFormatText, nil
+	}
+	if format == "md" {
+		return ReportFormatMarkdown, nil
+	}
+
+	for _, supported := range supportedReportFormats {
+		if format == supported {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("formato de relatório inválido: %s (use %s)", format, strings.Join(supportedReportFormats, ", "))
+}
+
+// WriteReport escreve o relatório no formato solicitado
+func (pc *PRChampion) WriteReport(format string, w io.Writer) error {
+	switch format {
+	case ReportFormatText, "":
+		pc.WriteTextReport(w)
+		return nil
+	case ReportFormatJSON:
+		return pc.WriteJSONReport(w)
+	case ReportFormatMarkdown:
+		return pc.WriteMarkdownReport(w)
+	case ReportFormatHTML:
+		return pc.WriteHTMLReport(w)
+	case ReportFormatCSV:
+		return pc.WriteCSVReport(w)
+	default:
+		return fmt.Errorf("formato de relatório não suportado: %s", format)
+	}
+}
+
+// openReportFile abre (ou cria) o arquivo de --out sem truncá-lo, validando o caminho antes da busca;
+// o conteúdo anterior só é descartado quando o novo relatório é escrito
+func openReportFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar arquivo de relatório: %v", err)
+	}
+	return file, nil
+}
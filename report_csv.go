@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// csvReportHeader define as colunas do export CSV (uma linha por semana, usuário e repositório)
+var csvReportHeader = []string{
+	"week_start",
+	"week_end",
+	"username",
+	"repository",
+	"prs_merged",
+	"comments",
+	"weighted_comment_score",
+	"pr_winner",
+	"comment_winner",
+	"quality_winner",
+}
+
+// weightedScoreEpsilon é a diferença de arredondamento ignorada ao atribuir a pontuação ponderada restante
+const weightedScoreEpsilon = 1e-9
+
+// csvRow acumula as métricas de um usuário em um repositório durante uma semana
+type csvRow struct {
+	prs           int
+	comments      int
+	weightedScore float64
+}
+
+// WriteCSVReport escreve as métricas brutas por semana, usuário e repositório em CSV.
+// Métricas sem repositório atribuído são exportadas com a coluna repository vazia,
+// de forma que a soma das linhas de uma semana sempre bate com os totais semanais.
+func (pc *PRChampion) WriteCSVReport(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvReportHeader); err != nil {
+		return fmt.Errorf("erro ao escrever cabeçalho CSV: %v", err)
+	}
+
+	for _, week := range pc.weeklyData {
+		rows := weekCSVRows(week)
+
+		usernames := make([]string, 0, len(rows))
+		for username := range rows {
+			usernames = append(usernames, username)
+		}
+		sort.Strings(usernames)
+
+		for _, username := range usernames {
+			repos := make([]string, 0, len(rows[username]))
+			for repo := range rows[username] {
+				repos = append(repos, repo)
+			}
+			sort.Strings(repos)
+
+			for _, repo := range repos {
+				row := rows[username][repo]
+				record := []string{
+					week.StartDate.Format("2006-01-02"),
+					week.EndDate.Format("2006-01-02"),
+					username,
+					repo,
+					strconv.Itoa(row.prs),
+					strconv.Itoa(row.comments),
+					strconv.FormatFloat(row.weightedScore, 'f', -1, 64),
+					strconv.FormatBool(containsUser(week.prWinners(), username)),
+					strconv.FormatBool(containsUser(week.commentWinners(), username)),
+					strconv.FormatBool(containsUser(week.weightedCommentWinners(), username)),
+				}
+				if err := writer.Write(record); err != nil {
+					return fmt.Errorf("erro ao escrever linha CSV: %v", err)
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("erro ao finalizar CSV: %v", err)
+	}
+	return nil
+}
+
+// weekCSVRows agrupa as métricas de uma semana por usuário e repositório
+func weekCSVRows(week WeeklyData) map[string]map[string]*csvRow {
+	rows := make(map[string]map[string]*csvRow)
+	rowFor := func(username, repo string) *csvRow {
+		if rows[username] == nil {
+			rows[username] = make(map[string]*csvRow)
+		}
+		if rows[username][repo] == nil {
+			rows[username][repo] = &csvRow{}
+		}
+		return rows[username][repo]
+	}
+
+	attributedPRs := make(map[string]int)
+	for repo, userPRs := range week.RepoData {
+		for username, count := range userPRs {
+			rowFor(username, repo).prs += count
+			attributedPRs[username] += count
+		}
+	}
+
+	attributedComments := make(map[string]int)
+	for repo, userComments := range week.RepoComments {
+		for username, count := range userComments {
+			rowFor(username, repo).comments += count
+			attributedComments[username] += count
+		}
+	}
+
+	attributedWeighted := make(map[string]float64)
+	for repo, userWeighted := range week.RepoWeightedComments {
+		for username, score := range userWeighted {
+			rowFor(username, repo).weightedScore += score
+			attributedWeighted[username] += score
+		}
+	}
+
+	// O que não foi atribuído a nenhum repositório vai para a linha sem repositório
+	for username, count := range week.UserPRs {
+		if remaining := count - attributedPRs[username]; remaining > 0 {
+			rowFor(username, "").prs += remaining
+		}
+	}
+	for username, count := range week.UserComments {
+		if remaining := count - attributedComments[username]; remaining > 0 {
+			rowFor(username, "").comments += remaining
+		}
+	}
+	for username, score := range week.UserWeightedComments {
+		// A pontuação ponderada pode ser negativa; ignora apenas a diferença de arredondamento
+		if remaining := score - attributedWeighted[username]; math.Abs(remaining) > weightedScoreEpsilon {
+			rowFor(username, "").weightedScore += remaining
+		}
+	}
+
+	return rows
+}
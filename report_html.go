@@ -0,0 +1,98 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// reportAssets contém o template, o CSS e o JS do dashboard HTML.
+// Tudo é embutido no binário para que o relatório funcione sem acesso à rede.
+//
+//go:embed assets/report.html.tmpl assets/report.css assets/report.js
+var reportAssets embed.FS
+
+// htmlReportPage são os dados usados para renderizar o template do dashboard
+type htmlReportPage struct {
+	Title       string
+	GeneratedAt string
+	PeriodLabel string // nome do período de apuração em minúsculas (ex: "semana", "sprint")
+	Report      *JSONReport
+	Data        htmlReportData
+	CSS         template.CSS
+	JS          template.JS
+}
+
+// htmlReportData é serializado como JSON dentro da página e consumido pelo JS dos gráficos
+type htmlReportData struct {
+	Report     *JSONReport      `json:"report"`
+	Cumulative map[string][]int `json:"cumulative"`
+}
+
+// WriteHTMLReport escreve um dashboard HTML autocontido no writer informado
+func (pc *PRChampion) WriteHTMLReport(w io.Writer) error {
+	tmplContent, err := reportAssets.ReadFile("assets/report.html.tmpl")
+	if err != nil {
+		return fmt.Errorf("erro ao carregar template HTML: %v", err)
+	}
+	css, err := reportAssets.ReadFile("assets/report.css")
+	if err != nil {
+		return fmt.Errorf("erro ao carregar CSS do relatório: %v", err)
+	}
+	js, err := reportAssets.ReadFile("assets/report.js")
+	if err != nil {
+		return fmt.Errorf("erro ao carregar JS do relatório: %v", err)
+	}
+
+	tmpl, err := template.New("report").Parse(string(tmplContent))
+	if err != nil {
+		return fmt.Errorf("erro ao interpretar template HTML: %v", err)
+	}
+
+	report := pc.BuildJSONReport()
+	page := htmlReportPage{
+		Title: fmt.Sprintf("%s a %s",
+			pc.startDate.Format("02/01/2006"), pc.endDate.Format("02/01/2006")),
+		GeneratedAt: report.GeneratedAt.Format("02/01/2006 15:04"),
+		PeriodLabel: strings.ToLower(pc.weekCalendar().Period.Label()),
+		Report:      report,
+		Data: htmlReportData{
+			Report:     report,
+			Cumulative: pc.cumulativeScoreSeries(),
+		},
+		CSS: template.CSS(css),
+		JS:  template.JS(js),
+	}
+
+	if err := tmpl.Execute(w, page); err != nil {
+		return fmt.Errorf("erro ao renderizar relatório HTML: %v", err)
+	}
+	return nil
+}
+
+// cumulativeScoreSeries retorna, para cada usuário, a pontuação acumulada ao final de cada semana
+func (pc *PRChampion) cumulativeScoreSeries() map[string][]int {
+	series := make(map[string][]int)
+	for username := range pc.userStats {
+		series[username] = make([]int, len(pc.weeklyData))
+	}
+
+	points := pc.scoringConfig().WeeklyPoints
+	totals := make(map[string]int)
+	for i, week := range pc.weeklyData {
+		for _, winner := range week.prWinners() {
+			totals[winner] += points.PRChampion
+			if series[winner] == nil {
+				series[winner] = make([]int, len(pc.weeklyData))
+			}
+		}
+
+		for username := range series {
+			series[username][i] = totals[username]
+		}
+	}
+
+	return series
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// JSONReportSchemaVersion é a versão do schema do relatório JSON.
+// Deve ser incrementada sempre que um campo existente mudar de nome, tipo ou significado;
+// campos novos podem ser adicionados sem alterar a versão.
+// Versão 2: os vencedores de cada semana (winners, comment_winners, weighted_comment_winners e
+// repo_winners) passaram a ser listas, substituindo os campos winner, comment_winner,
+// weighted_comment_winner e repo_all_winners.
+const JSONReportSchemaVersion = 2
+
+// JSONReport é a representação serializável do relatório completo
+type JSONReport struct {
+	SchemaVersion int               `json:"schema_version"`
+	GeneratedAt   time.Time         `json:"generated_at"`
+	StartDate     time.Time         `json:"start_date"`
+	EndDate       time.Time         `json:"end_date"`
+	Period        string            `json:"period"`
+	Incomplete    bool              `json:"incomplete"`
+	Errors        []string          `json:"errors"`
+	Repositories  []JSONRepository  `json:"repositories"`
+	Weeks         []JSONWeek        `json:"weeks"`
+	Users         []JSONUserStats   `json:"users"`
+	Leaderboards  []JSONLeaderboard `json:"leaderboards"`
+	Usage         *JSONUsage        `json:"usage,omitempty"`
+}
+
+// JSONUsage resume o uso do cache e da API na execução que gerou o relatório
+type JSONUsage struct {
+	Cache           []JSONCacheUsage `json:"cache"`
+	APICalls        int              `json:"api_calls"`
+	APISeconds      float64          `json:"api_seconds"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	RateLimit       *JSONRateLimit   `json:"rate_limit,omitempty"`
+}
+
+// JSONCacheUsage conta os hits e misses do cache de um recurso (prs, issue_comments, review_comments ou reactions)
+type JSONCacheUsage struct {
+	Resource string  `json:"resource"`
+	Hits     int     `json:"hits"`
+	Misses   int     `json:"misses"`
+	HitRate  float64 `json:"hit_rate"`
+}
+
+// JSONRateLimit é o último rate limit da API conhecido ao final da execução
+type JSONRateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// JSONLeaderboard representa o ranking completo de um Scorer no relatório JSON
+type JSONLeaderboard struct {
+	Scorer  string                 `json:"scorer"`
+	Unit    string                 `json:"unit"`
+	Entries []JSONLeaderboardEntry `json:"entries"`
+}
+
+// JSONLeaderboardEntry representa a posição de um usuário em um ranking
+type JSONLeaderboardEntry struct {
+	Position int     `json:"position"`
+	Username string  `json:"username"`
+	Score    float64 `json:"score"`
+}
+
+// JSONRepository representa um repositório analisado no relatório JSON
+type JSONRepository struct {
+	Host               string   `json:"host"`
+	Owner              string   `json:"owner"`
+	Name               string   `json:"name"`
+	ProductionBranches []string `json:"production_branches"`
+}
+
+// JSONWeek representa os dados de uma semana no relatório JSON. Os vencedores são sempre listas:
+// com --tie-policy shared, todos os empatados aparecem
+type JSONWeek struct {
+	StartDate              time.Time                     `json:"start_date"`
+	EndDate                time.Time                     `json:"end_date"`
+	UserPRs                map[string]int                `json:"user_prs"`
+	Winners                []string                      `json:"winners"`
+	RepoData               map[string]map[string]int     `json:"repo_data"`
+	UserComments           map[string]int                `json:"user_comments"`
+	CommentWinners         []string                      `json:"comment_winners"`
+	UserWeightedComments   map[string]float64            `json:"user_weighted_comments"`
+	WeightedCommentWinners []string                      `json:"weighted_comment_winners"`
+	RepoComments           map[string]map[string]int     `json:"repo_comments"`
+	RepoWeightedComments   map[string]map[string]float64 `json:"repo_weighted_comments"`
+	RepoWinners            map[string][]string           `json:"repo_winners"`
+	TiePolicy              string                        `json:"tie_policy"`
+}
+
+// JSONUserStats representa as estatísticas de um usuário no relatório JSON
+type JSONUserStats struct {
+	Username                   string         `json:"username"`
+	PRsCount                   int            `json:"prs_count"`
+	WeeklyWins                 int            `json:"weekly_wins"`
+	TotalScore                 int            `json:"total_score"`
+	RepoStats                  map[string]int `json:"repo_stats"`
+	CommentsCount              int            `json:"comments_count"`
+	CommentWeeklyWins          int            `json:"comment_weekly_wins"`
+	CommentScore               int            `json:"comment_score"`
+	WeightedCommentScore       float64        `json:"weighted_comment_score"`
+	WeightedCommentWeeklyWins  int            `json:"weighted_comment_weekly_wins"`
+	WeightedCommentWeeklyScore int            `json:"weighted_comment_weekly_score"`
+}
+
+// BuildJSONReport monta a estrutura serializável do relatório
+func (pc *PRChampion) BuildJSONReport() *JSONReport {
+	report := &JSONReport{
+		SchemaVersion: JSONReportSchemaVersion,
+		GeneratedAt:   time.Now(),
+		StartDate:     pc.startDate,
+		EndDate:       pc.endDate,
+		Period:        pc.weekCalendar().Period.String(),
+		Incomplete:    pc.Incomplete(),
+		Errors:        nonNilStrings(pc.FetchErrors()),
+		Repositories:  []JSONRepository{},
+		Weeks:         []JSONWeek{},
+		Users:         []JSONUserStats{},
+		Leaderboards:  []JSONLeaderboard{},
+		Usage:         pc.buildJSONUsage(),
+	}
+
+	for _, repo := range pc.repositories {
+		branches := repo.ProductionBranches
+		if branches == nil {
+			branches = []string{}
+		}
+		report.Repositories = append(report.Repositories, JSONRepository{
+			Host:               pc.repositoryHost(repo),
+			Owner:              repo.Owner,
+			Name:               repo.Name,
+			ProductionBranches: branches,
+		})
+	}
+
+	for _, week := range pc.weeklyData {
+		report.Weeks = append(report.Weeks, JSONWeek{
+			StartDate:              week.StartDate,
+			EndDate:                week.EndDate,
+			UserPRs:                nonNilIntMap(week.UserPRs),
+			Winners:                nonNilStrings(week.prWinners()),
+			RepoData:               nonNilNestedIntMap(week.RepoData),
+			UserComments:           nonNilIntMap(week.UserComments),
+			CommentWinners:         nonNilStrings(week.commentWinners()),
+			UserWeightedComments:   nonNilFloatMap(week.UserWeightedComments),
+			WeightedCommentWinners: nonNilStrings(week.weightedCommentWinners()),
+			RepoComments:           nonNilNestedIntMap(week.RepoComments),
+			RepoWeightedComments:   nonNilNestedFloatMap(week.RepoWeightedComments),
+			RepoWinners:            nonNilStringSliceMap(week.RepoWinners),
+			TiePolicy:              week.TiePolicy,
+		})
+	}
+
+	// Usuários ordenados por nome para manter a saída estável entre execuções
+	usernames := make([]string, 0, len(pc.userStats))
+	for username := range pc.userStats {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	for _, username := range usernames {
+		stats := pc.userStats[username]
+		report.Users = append(report.Users, JSONUserStats{
+			Username:                   stats.Username,
+			PRsCount:                   stats.PRsCount,
+			WeeklyWins:                 stats.WeeklyWins,
+			TotalScore:                 stats.TotalScore,
+			RepoStats:                  nonNilIntMap(stats.RepoStats),
+			CommentsCount:              stats.CommentsCount,
+			CommentWeeklyWins:          stats.CommentWeeklyWins,
+			CommentScore:               stats.CommentScore,
+			WeightedCommentScore:       stats.WeightedCommentScore,
+			WeightedCommentWeeklyWins:  stats.WeightedCommentWeeklyWins,
+			WeightedCommentWeeklyScore: stats.WeightedCommentWeeklyScore,
+		})
+	}
+
+	for _, scorer := range pc.activeScorers() {
+		leaderboard := JSONLeaderboard{
+			Scorer:  scorer.Name(),
+			Unit:    scorer.Unit(),
+			Entries: []JSONLeaderboardEntry{},
+		}
+		for i, entry := range pc.Leaderboard(scorer, 0) {
+			leaderboard.Entries = append(leaderboard.Entries, JSONLeaderboardEntry{
+				Position: i + 1,
+				Username: entry.Username,
+				Score:    entry.Score,
+			})
+		}
+		report.Leaderboards = append(report.Leaderboards, leaderboard)
+	}
+
+	return report
+}
+
+// WriteJSONReport escreve o relatório em formato JSON no writer informado
+func (pc *PRChampion) WriteJSONReport(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(pc.BuildJSONReport()); err != nil {
+		return fmt.Errorf("erro ao serializar relatório JSON: %v", err)
+	}
+	return nil
+}
+
+// nonNilIntMap garante que mapas vazios sejam serializados como {} em vez de null
+func nonNilIntMap(m map[string]int) map[string]int {
+	if m == nil {
+		return map[string]int{}
+	}
+	return m
+}
+
+// nonNilFloatMap garante que mapas vazios sejam serializados como {} em vez de null
+func nonNilFloatMap(m map[string]float64) map[string]float64 {
+	if m == nil {
+		return map[string]float64{}
+	}
+	return m
+}
+
+// nonNilNestedIntMap garante que mapas vazios sejam serializados como {} em vez de null
+func nonNilNestedIntMap(m map[string]map[string]int) map[string]map[string]int {
+	if m == nil {
+		return map[string]map[string]int{}
+	}
+	return m
+}
+
+// nonNilNestedFloatMap garante que mapas vazios sejam serializados como {} em vez de null
+func nonNilNestedFloatMap(m map[string]map[string]float64) map[string]map[string]float64 {
+	if m == nil {
+		return map[string]map[string]float64{}
+	}
+	return m
+}
+
+// nonNilStrings garante que listas vazias sejam serializadas como [] em vez de null
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// nonNilStringSliceMap garante que mapas vazios sejam serializados como {} em vez de null
+func nonNilStringSliceMap(m map[string][]string) map[string][]string {
+	if m == nil {
+		return map[string][]string{}
+	}
+	return m
+}
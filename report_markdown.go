@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdownReport escreve o relatório em Markdown (GitHub-flavored) no writer informado
+func (pc *PRChampion) WriteMarkdownReport(w io.Writer) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# 🏆 Relatório PR Champion - %s a %s\n\n",
+		pc.startDate.Format("02/01/2006"), pc.endDate.Format("02/01/2006"))
+
+	// Lista dos repositórios analisados
+	fmt.Fprintf(&sb, "**Repositórios analisados (%d):** ", len(pc.repositories))
+	var analyzedRepos []string
+	for _, repo := range pc.repositories {
+		analyzedRepos = append(analyzedRepos, fmt.Sprintf("`%s/%s`", repo.Owner, repo.Name))
+	}
+	sb.WriteString(strings.Join(analyzedRepos, ", "))
+	sb.WriteString("\n\n")
+
+	// Aviso de relatório incompleto
+	if pc.Incomplete() {
+		fmt.Fprintf(&sb, "> ⚠️ **Relatório incompleto:** %d falha(s) ao buscar dados do GitHub; os rankings podem estar subestimados.\n",
+			len(pc.fetchErrors))
+		for _, message := range limitFetchErrors(pc.fetchErrors, maxReportedFetchErrors) {
+			fmt.Fprintf(&sb, "> - %s\n", message)
+		}
+		sb.WriteString("\n")
+	}
+
+	// Resumo por período
+	period := pc.weekCalendar().Period
+	fmt.Fprintf(&sb, "## 📅 %s\n\n", period.SummaryTitle())
+	if len(pc.weeklyData) == 0 {
+		sb.WriteString("_Nenhuma atividade encontrada no período analisado._\n\n")
+	} else {
+		fmt.Fprintf(&sb, "| %s | 🥇 Campeão PRs | PRs | ⭐ Campeão Qualidade | Pontos |\n", period.Label())
+		sb.WriteString("| --- | --- | ---: | --- | ---: |\n")
+		for _, week := range pc.weeklyData {
+			prWinner, prCount := "-", "-"
+			if winners := week.prWinners(); len(winners) > 0 {
+				prWinner = markdownEscape(strings.Join(winners, ", "))
+				prCount = fmt.Sprintf("%d", week.UserPRs[winners[0]])
+			}
+
+			qualityWinner, qualityScore := "-", "-"
+			if winners := week.weightedCommentWinners(); len(winners) > 0 {
+				qualityWinner = markdownEscape(strings.Join(winners, ", "))
+				qualityScore = fmt.Sprintf("%.1f", week.UserWeightedComments[winners[0]])
+			}
+
+			fmt.Fprintf(&sb, "| %s - %s | %s | %s | %s | %s |\n",
+				week.StartDate.Format("02/01"), week.EndDate.Format("02/01/2006"),
+				prWinner, prCount, qualityWinner, qualityScore)
+		}
+		fmt.Fprintf(&sb, "\n_Política de desempate: %s._\n\n", pc.activeTiePolicy())
+	}
+
+	// Rankings dos Scorers selecionados
+	for _, scorer := range pc.activeScorers() {
+		fmt.Fprintf(&sb, "## %s\n\n", strings.TrimSpace(scorer.Title()))
+
+		entries := pc.Leaderboard(scorer, 5)
+		if len(entries) == 0 {
+			sb.WriteString("_Nenhuma pontuação registrada no período analisado._\n\n")
+			continue
+		}
+
+		// Scorers detalhados ganham uma coluna por informação, no lugar da pontuação
+		detailed, ok := scorer.(DetailedScorer)
+		if !ok {
+			fmt.Fprintf(&sb, "| Posição | Usuário | %s |\n", scorer.Unit())
+			sb.WriteString("| ---: | --- | ---: |\n")
+			for i, entry := range entries {
+				fmt.Fprintf(&sb, "| %s %d° | %s | %s |\n", rankingMedal(i), i+1, markdownEscape(entry.Username), formatScore(entry.Score))
+			}
+			sb.WriteString("\n")
+			continue
+		}
+
+		columns := detailed.Details(pc.statsFor(entries[0].Username))
+		sb.WriteString("| Posição | Usuário |")
+		for _, column := range columns {
+			fmt.Fprintf(&sb, " %s |", column.Label)
+		}
+		sb.WriteString("\n| ---: | --- |" + strings.Repeat(" ---: |", len(columns)) + "\n")
+		for i, entry := range entries {
+			fmt.Fprintf(&sb, "| %s %d° | %s |", rankingMedal(i), i+1, markdownEscape(entry.Username))
+			for _, detail := range detailed.Details(pc.statsFor(entry.Username)) {
+				fmt.Fprintf(&sb, " %s |", detail.Value)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	// Ranking por repositório
+	repoNames := pc.getRepositoryNames()
+	if len(repoNames) > 0 {
+		sb.WriteString("## 📁 Ranking por Repositório\n")
+		for _, repo := range repoNames {
+			fmt.Fprintf(&sb, "\n### `%s`\n\n", repo)
+
+			topRepoUsers := pc.getTopUsersForRepo(repo, 5)
+			if len(topRepoUsers) == 0 {
+				sb.WriteString("_Nenhum PR mergeado no período analisado._\n")
+				continue
+			}
+
+			fmt.Fprintf(&sb, "| %s | 🥇 Campeão | PRs |\n", period.Label())
+			sb.WriteString("| --- | --- | ---: |\n")
+			for _, week := range pc.weeklyData {
+				winners := week.RepoWinners[repo]
+				if len(winners) == 0 {
+					continue
+				}
+				fmt.Fprintf(&sb, "| %s - %s | %s | %d |\n",
+					week.StartDate.Format("02/01"), week.EndDate.Format("02/01/2006"),
+					markdownEscape(strings.Join(winners, ", ")), week.RepoData[repo][winners[0]])
+			}
+
+			sb.WriteString("\n| Posição | Usuário | Total de PRs |\n")
+			sb.WriteString("| ---: | --- | ---: |\n")
+			for i, user := range topRepoUsers {
+				fmt.Fprintf(&sb, "| %s %d° | %s | %d |\n", rankingMedal(i), i+1, markdownEscape(user.Username), user.PRsCount)
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("erro ao escrever relatório Markdown: %v", err)
+	}
+	return nil
+}
+
+// rankingMedal retorna a medalha usada para a posição (base 0) nos rankings
+func rankingMedal(index int) string {
+	medals := []string{"🥇", "🥈", "🥉", "🏅", "🎖️"}
+	if index < len(medals) {
+		return medals[index]
+	}
+	return ""
+}
+
+// markdownEscape escapa caracteres que quebrariam uma célula de tabela Markdown
+func markdownEscape(text string) string {
+	replacer := strings.NewReplacer("|", "\\|", "\n", " ", "_", "\\_", "*", "\\*")
+	return replacer.Replace(text)
+}
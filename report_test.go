@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newReportTestChampion cria um PRChampion com dados fixos para os testes de relatório
+func newReportTestChampion() *PRChampion {
+	startDate, _ := time.Parse("2006-01-02", "2024-09-30")
+	endDate, _ := time.Parse("2006-01-02", "2024-10-13")
+
+	pc := &PRChampion{
+		startDate: startDate,
+		endDate:   endDate,
+		userStats: make(map[string]*UserStats),
+		repositories: []Repository{
+			{Owner: "test", Name: "repo1", ProductionBranches: []string{"main"}},
+		},
+	}
+
+	pc.weeklyData = []WeeklyData{
+		{
+			StartDate:             startDate,
+			EndDate:               startDate.Add(6 * 24 * time.Hour),
+			UserPRs:               map[string]int{"user1": 3, "user2": 1},
+			Winner:                "user1",
+			UserComments:          map[string]int{"user2": 4},
+			CommentWinner:         "user2",
+			UserWeightedComments:  map[string]float64{"user2": 6.5},
+			WeightedCommentWinner: "user2",
+		},
+		{
+			StartDate: startDate.Add(7 * 24 * time.Hour),
+			EndDate:   startDate.Add(13 * 24 * time.Hour),
+			UserPRs:   map[string]int{"user2": 3},
+			Winner:    "user2",
+		},
+	}
+
+	pc.calculateUserStats()
+	return pc
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	pc := newReportTestChampion()
+
+	var buf bytes.Buffer
+	if err := pc.WriteReport(ReportFormatJSON, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+
+	if report.SchemaVersion != JSONReportSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", JSONReportSchemaVersion, report.SchemaVersion)
+	}
+
+	if len(report.Repositories) != 1 || report.Repositories[0].Name != "repo1" || report.Repositories[0].Host != "github.com" {
+		t.Errorf("Expected repo1 in repositories, got %v", report.Repositories)
+	}
+
+	if len(report.Weeks) != 2 {
+		t.Fatalf("Expected 2 weeks, got %d", len(report.Weeks))
+	}
+
+	if !reflect.DeepEqual(report.Weeks[0].Winners, []string{"user1"}) || !reflect.DeepEqual(report.Weeks[0].WeightedCommentWinners, []string{"user2"}) {
+		t.Errorf("Unexpected winners in first week: %+v", report.Weeks[0])
+	}
+
+	if report.Weeks[1].UserComments == nil {
+		t.Error("Expected empty user_comments to be serialized as an object")
+	}
+
+	if len(report.Users) != 2 || report.Users[0].Username != "user1" || report.Users[1].Username != "user2" {
+		t.Fatalf("Expected users sorted by username, got %+v", report.Users)
+	}
+
+	if report.Users[1].TotalScore != 1 || report.Users[1].WeightedCommentScore != 6.5 {
+		t.Errorf("Unexpected stats for user2: %+v", report.Users[1])
+	}
+}
+
+func TestReportsMarkIncompleteData(t *testing.T) {
+	pc := newReportTestChampion()
+
+	var buf bytes.Buffer
+	if err := pc.WriteReport(ReportFormatJSON, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	var complete JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &complete); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	if complete.Incomplete || complete.Errors == nil || len(complete.Errors) != 0 {
+		t.Errorf("Expected complete report with empty errors, got incomplete=%v errors=%v", complete.Incomplete, complete.Errors)
+	}
+
+	pc.recordFetchError(fmt.Errorf("erro ao buscar comentários do PR #7 em test/repo1: rate limit"))
+
+	buf.Reset()
+	if err := pc.WriteReport(ReportFormatJSON, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	var incomplete JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &incomplete); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	if !incomplete.Incomplete || len(incomplete.Errors) != 1 {
+		t.Errorf("Expected incomplete report with 1 error, got incomplete=%v errors=%v", incomplete.Incomplete, incomplete.Errors)
+	}
+
+	buf.Reset()
+	pc.WriteTextReport(&buf)
+	if !strings.Contains(buf.String(), "RELATÓRIO INCOMPLETO") || !strings.Contains(buf.String(), "PR #7") {
+		t.Errorf("Expected text report to flag incomplete data, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := pc.WriteReport(ReportFormatMarkdown, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "**Relatório incompleto:**") {
+		t.Errorf("Expected markdown report to flag incomplete data, got:\n%s", buf.String())
+	}
+}
+
+func TestTextReportCacheSection(t *testing.T) {
+	pc := newReportTestChampion()
+	pc.dbPath = "/tmp/custom.db"
+
+	var buf bytes.Buffer
+	pc.WriteTextReport(&buf)
+	output := buf.String()
+	if !strings.Contains(output, "Local do banco: /tmp/custom.db") || !strings.Contains(output, "--clear-database") {
+		t.Errorf("Expected the configured database and cache hints, got:\n%s", output)
+	}
+
+	pc.offline = true
+	buf.Reset()
+	pc.WriteTextReport(&buf)
+	output = buf.String()
+	if !strings.Contains(output, "Modo offline") || strings.Contains(output, "cache em banco SQLite ativo") || strings.Contains(output, "--clear-database") {
+		t.Errorf("Expected offline cache section without cache hints, got:\n%s", output)
+	}
+}
+
+func TestWriteMarkdownReport(t *testing.T) {
+	pc := newReportTestChampion()
+
+	var buf bytes.Buffer
+	if err := pc.WriteReport(ReportFormatMarkdown, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	output := buf.String()
+
+	expectedLines := []string{
+		"## 📅 Resumo Semanal",
+		"| 30/09 - 06/10/2024 | user1 | 3 | user2 | 6.5 |",
+		"| 07/10 - 13/10/2024 | user2 | 3 | - | - |",
+		"| 🥇 1° | user2 | 1 | 1 | 4 |",
+		"| 🥇 1° | user2 | 1 | 1 | 6.5 |",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected markdown output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	pc := newReportTestChampion()
+
+	var buf bytes.Buffer
+	if err := pc.WriteReport(ReportFormatHTML, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	output := buf.String()
+
+	// O dashboard deve ser autocontido: nada de scripts ou estilos externos
+	for _, external := range []string{"<script src", "<link", "@import"} {
+		if strings.Contains(output, external) {
+			t.Errorf("Expected self-contained HTML, found %q", external)
+		}
+	}
+
+	for _, expected := range []string{`id="report-data"`, `"schema_version":2`, `"cumulative":`, "groupedBars"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected HTML output to contain %q", expected)
+		}
+	}
+}
+
+func TestCumulativeScoreSeries(t *testing.T) {
+	pc := newReportTestChampion()
+
+	series := pc.cumulativeScoreSeries()
+
+	if got := series["user1"]; len(got) != 2 || got[0] != 1 || got[1] != 1 {
+		t.Errorf("Expected user1 cumulative [1 1], got %v", got)
+	}
+	if got := series["user2"]; len(got) != 2 || got[0] != 0 || got[1] != 1 {
+		t.Errorf("Expected user2 cumulative [0 1], got %v", got)
+	}
+}
+
+func TestWriteCSVReport(t *testing.T) {
+	pc := newReportTestChampion()
+	pc.weeklyData[0].RepoData = map[string]map[string]int{
+		"test/repo1": {"user1": 2},
+	}
+	pc.weeklyData[0].RepoComments = map[string]map[string]int{
+		"test/repo1": {"user2": 4},
+	}
+	pc.weeklyData[0].RepoWeightedComments = map[string]map[string]float64{
+		"test/repo1": {"user2": 6.5},
+	}
+
+	var buf bytes.Buffer
+	if err := pc.WriteReport(ReportFormatCSV, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	expected := strings.Join([]string{
+		strings.Join(csvReportHeader, ","),
+		"2024-09-30,2024-10-06,user1,,1,0,0,true,false,false",
+		"2024-09-30,2024-10-06,user1,test/repo1,2,0,0,true,false,false",
+		"2024-09-30,2024-10-06,user2,,1,0,0,false,true,true",
+		"2024-09-30,2024-10-06,user2,test/repo1,0,4,6.5,false,true,true",
+		"2024-10-07,2024-10-13,user2,,3,0,0,true,false,false",
+	}, "\n") + "\n"
+
+	if buf.String() != expected {
+		t.Errorf("Unexpected CSV output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestWeekCSVRowsKeepUnattributedWeightedScore(t *testing.T) {
+	week := WeeklyData{
+		UserComments:         map[string]int{"user2": 4},
+		UserWeightedComments: map[string]float64{"user2": 6.5, "user3": -1},
+		RepoComments:         map[string]map[string]int{"test/repo1": {"user2": 3}},
+		RepoWeightedComments: map[string]map[string]float64{"test/repo1": {"user2": 4}},
+	}
+
+	rows := weekCSVRows(week)
+
+	// A soma das linhas de cada usuário bate com os totais semanais, inclusive a pontuação ponderada
+	if row := rows["user2"][""]; row == nil || row.comments != 1 || row.weightedScore != 2.5 {
+		t.Errorf("Expected 1 comment and 2.5 points without repository for user2, got %+v", row)
+	}
+	if row := rows["user3"][""]; row == nil || row.weightedScore != -1 {
+		t.Errorf("Expected negative score without repository for user3, got %+v", row)
+	}
+}
+
+func TestNormalizeReportFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"", ReportFormatText, false},
+		{"TEXT", ReportFormatText, false},
+		{" json ", ReportFormatJSON, false},
+		{"md", ReportFormatMarkdown, false},
+		{"xml", "", true},
+	}
+
+	for _, test := range tests {
+		result, err := normalizeReportFormat(test.input)
+		if test.hasError {
+			if err == nil {
+				t.Errorf("Expected error for format %q, but got none", test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for format %q: %v", test.input, err)
+		} else if result != test.expected {
+			t.Errorf("For format %q, expected %s, got %s", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestOpenReportFile(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := openReportFile(filepath.Join(dir, "missing", "report.json")); err == nil {
+		t.Error("Expected an error for a directory that does not exist")
+	}
+
+	// O relatório anterior é mantido até que o novo seja escrito
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, []byte("previous"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := openReportFile(path)
+	if err != nil {
+		t.Fatalf("openReportFile() error = %v", err)
+	}
+	defer file.Close()
+
+	if content, _ := os.ReadFile(path); string(content) != "previous" {
+		t.Errorf("Expected previous report to be kept, got %q", content)
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// ScoredComment representa um comentário válido (após filtros) já pontuado pelas reações
+type ScoredComment struct {
+	Username      string
+	Repo          string // owner/repo
+	PRNumber      int
+	CommentType   string // "issue" ou "review"
+	WeightedScore float64
+	CreatedAt     time.Time
+}
+
+// Scorer define uma estratégia de ranking plugável
+type Scorer interface {
+	// Name é o identificador usado na flag --scorer
+	Name() string
+	// Title é o título exibido no relatório
+	Title() string
+	// Unit é a unidade exibida ao lado da pontuação (ex: "PRs")
+	Unit() string
+	// ScorePR retorna a contribuição de um PR mergeado para a pontuação do autor
+	ScorePR(pr *github.PullRequest) float64
+	// ScoreComment retorna a contribuição de um comentário para a pontuação do autor
+	ScoreComment(comment ScoredComment) float64
+	// TieBreak indica se a deve ficar à frente de b quando as pontuações empatam
+	TieBreak(a, b *UserStats) bool
+}
+
+// UserStatsScorer é implementado pelos Scorers cuja pontuação vem das estatísticas consolidadas do
+// usuário (ex: pontos por vitórias semanais), e não da soma das contribuições de PRs e comentários.
+// Os rankings embutidos usam as estatísticas, que são a fonte única dos totais exibidos no relatório
+type UserStatsScorer interface {
+	ScoreUser(stats *UserStats) float64
+}
+
+// ScoreDetail é uma informação exibida sobre um usuário no ranking de um DetailedScorer
+type ScoreDetail struct {
+	Icon  string // Ícone exibido no relatório em texto
+	Label string
+	Value string
+	Unit  string // Unidade exibida após o valor no relatório em texto (opcional)
+}
+
+// Text retorna o valor seguido da unidade
+func (d ScoreDetail) Text() string {
+	if d.Unit == "" {
+		return d.Value
+	}
+	return d.Value + " " + d.Unit
+}
+
+// DetailedScorer é implementado pelos Scorers que exibem, em vez de uma linha por usuário, a
+// pontuação e outras informações do usuário; Details retorna sempre os mesmos itens, na mesma ordem
+type DetailedScorer interface {
+	Details(stats *UserStats) []ScoreDetail
+}
+
+// LeaderboardEntry representa a posição de um usuário em um ranking de Scorer
+type LeaderboardEntry struct {
+	Username string
+	Score    float64
+}
+
+// scorerRegistry guarda os Scorers disponíveis por nome
+var scorerRegistry = map[string]Scorer{}
+
+// scorerOrder mantém a ordem de registro para listagens estáveis
+var scorerOrder []string
+
+// DefaultScorers são os rankings exibidos quando --scorer não é informado
+var DefaultScorers = []string{"score", "weekly-quality", "prs", "weighted-comments"}
+
+// RegisterScorer adiciona um Scorer ao registro
+func RegisterScorer(scorer Scorer) {
+	name := scorer.Name()
+	if _, exists := scorerRegistry[name]; !exists {
+		scorerOrder = append(scorerOrder, name)
+	}
+	scorerRegistry[name] = scorer
+}
+
+// ScorerNames retorna os nomes dos Scorers registrados na ordem de registro
+func ScorerNames() []string {
+	return append([]string(nil), scorerOrder...)
+}
+
+// LookupScorers resolve uma lista de nomes para os Scorers registrados
+func LookupScorers(names []string) ([]Scorer, error) {
+	var scorers []Scorer
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		scorer, ok := scorerRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("scorer desconhecido: %s (disponíveis: %s)", name, strings.Join(ScorerNames(), ", "))
+		}
+		scorers = append(scorers, scorer)
+	}
+	return scorers, nil
+}
+
+func init() {
+	RegisterScorer(weeklyScoreScorer{})
+	RegisterScorer(weeklyQualityScorer{})
+	RegisterScorer(prCountScorer{})
+	RegisterScorer(commentCountScorer{})
+	RegisterScorer(weightedCommentScorer{})
+}
+
+// weeklyScoreScorer ranqueia usuários pelos pontos ganhos como campeões semanais de PRs
+type weeklyScoreScorer struct{}
+
+func (weeklyScoreScorer) Name() string                           { return "score" }
+func (weeklyScoreScorer) Title() string                          { return "🏅 RANKING GERAL POR PONTUAÇÃO" }
+func (weeklyScoreScorer) Unit() string                           { return "pontos" }
+func (weeklyScoreScorer) ScorePR(pr *github.PullRequest) float64 { return 0 }
+func (weeklyScoreScorer) ScoreComment(ScoredComment) float64     { return 0 }
+
+func (weeklyScoreScorer) ScoreUser(stats *UserStats) float64 {
+	return float64(stats.TotalScore)
+}
+
+// TieBreak favorece quem mergeou mais PRs
+func (weeklyScoreScorer) TieBreak(a, b *UserStats) bool {
+	return a.PRsCount > b.PRsCount
+}
+
+func (weeklyScoreScorer) Details(stats *UserStats) []ScoreDetail {
+	return []ScoreDetail{
+		{Icon: "📊", Label: "Pontuação", Value: fmt.Sprintf("%d", stats.TotalScore), Unit: "pontos"},
+		{Icon: "🏆", Label: "Vitórias semanais", Value: fmt.Sprintf("%d", stats.WeeklyWins)},
+		{Icon: "📋", Label: "Total de PRs", Value: fmt.Sprintf("%d", stats.PRsCount)},
+	}
+}
+
+// weeklyQualityScorer ranqueia usuários pelos pontos ganhos como campeões semanais de qualidade dos comentários
+type weeklyQualityScorer struct{}
+
+func (weeklyQualityScorer) Name() string { return "weekly-quality" }
+func (weeklyQualityScorer) Title() string {
+	return "🏅 RANKING SEMANAL POR QUALIDADE DOS COMENTÁRIOS"
+}
+func (weeklyQualityScorer) Unit() string                           { return "pontos" }
+func (weeklyQualityScorer) ScorePR(pr *github.PullRequest) float64 { return 0 }
+func (weeklyQualityScorer) ScoreComment(ScoredComment) float64     { return 0 }
+
+func (weeklyQualityScorer) ScoreUser(stats *UserStats) float64 {
+	return float64(stats.WeightedCommentWeeklyScore)
+}
+
+// TieBreak favorece quem venceu mais semanas por qualidade
+func (weeklyQualityScorer) TieBreak(a, b *UserStats) bool {
+	return a.WeightedCommentWeeklyWins > b.WeightedCommentWeeklyWins
+}
+
+func (weeklyQualityScorer) Details(stats *UserStats) []ScoreDetail {
+	return []ScoreDetail{
+		{Icon: "🏅", Label: "Pontuação semanal", Value: fmt.Sprintf("%d", stats.WeightedCommentWeeklyScore), Unit: "pontos"},
+		{Icon: "🏆", Label: "Vitórias semanais (qualidade)", Value: fmt.Sprintf("%d", stats.WeightedCommentWeeklyWins)},
+		{Icon: "⭐", Label: "Pontuação total com reações", Value: fmt.Sprintf("%.1f", stats.WeightedCommentScore), Unit: "pontos"},
+	}
+}
+
+// prCountScorer ranqueia usuários pelo número de PRs mergeados
+type prCountScorer struct{}
+
+func (prCountScorer) Name() string                           { return "prs" }
+func (prCountScorer) Title() string                          { return "📈 TOP 5 POR TOTAL DE PRS" }
+func (prCountScorer) Unit() string                           { return "PRs" }
+func (prCountScorer) ScorePR(pr *github.PullRequest) float64 { return 1 }
+func (prCountScorer) ScoreComment(ScoredComment) float64     { return 0 }
+
+func (prCountScorer) ScoreUser(stats *UserStats) float64 {
+	return float64(stats.PRsCount)
+}
+
+// TieBreak favorece quem venceu mais semanas
+func (prCountScorer) TieBreak(a, b *UserStats) bool {
+	return a.WeeklyWins > b.WeeklyWins
+}
+
+// commentCountScorer ranqueia usuários pelo número de comentários feitos
+type commentCountScorer struct{}
+
+func (commentCountScorer) Name() string                           { return "comments" }
+func (commentCountScorer) Title() string                          { return "💬 TOP 5 POR NÚMERO DE COMENTÁRIOS" }
+func (commentCountScorer) Unit() string                           { return "comentários" }
+func (commentCountScorer) ScorePR(pr *github.PullRequest) float64 { return 0 }
+func (commentCountScorer) ScoreComment(ScoredComment) float64     { return 1 }
+
+func (commentCountScorer) ScoreUser(stats *UserStats) float64 {
+	return float64(stats.CommentsCount)
+}
+
+// TieBreak favorece quem tem maior pontuação ponderada
+func (commentCountScorer) TieBreak(a, b *UserStats) bool {
+	return a.WeightedCommentScore > b.WeightedCommentScore
+}
+
+// weightedCommentScorer ranqueia usuários pela pontuação ponderada por reações
+type weightedCommentScorer struct{}
+
+func (weightedCommentScorer) Name() string                           { return "weighted-comments" }
+func (weightedCommentScorer) Title() string                          { return "💬 TOP 5 POR QUALIDADE DE COMENTÁRIOS" }
+func (weightedCommentScorer) Unit() string                           { return "pontos" }
+func (weightedCommentScorer) ScorePR(pr *github.PullRequest) float64 { return 0 }
+func (weightedCommentScorer) ScoreComment(comment ScoredComment) float64 {
+	return comment.WeightedScore
+}
+
+func (weightedCommentScorer) ScoreUser(stats *UserStats) float64 {
+	return stats.WeightedCommentScore
+}
+
+// TieBreak favorece quem comentou mais
+func (weightedCommentScorer) TieBreak(a, b *UserStats) bool {
+	return a.CommentsCount > b.CommentsCount
+}
+
+// SetScorers define quais rankings aparecem no relatório
+func (pc *PRChampion) SetScorers(scorers []Scorer) {
+	pc.scorers = scorers
+}
+
+// activeScorers retorna os Scorers selecionados (ou os padrão)
+func (pc *PRChampion) activeScorers() []Scorer {
+	if pc.scorers == nil {
+		scorers, _ := LookupScorers(DefaultScorers)
+		return scorers
+	}
+	return pc.scorers
+}
+
+// addScorerPR acumula a contribuição de um PR em todos os Scorers ativos
+func (pc *PRChampion) addScorerPR(pr *github.PullRequest) {
+	for _, scorer := range pc.activeScorers() {
+		pc.addScorerPoints(scorer.Name(), pr.User.GetLogin(), scorer.ScorePR(pr))
+	}
+}
+
+// addScorerComment acumula a contribuição de um comentário em todos os Scorers ativos
+func (pc *PRChampion) addScorerComment(comment ScoredComment) {
+	for _, scorer := range pc.activeScorers() {
+		pc.addScorerPoints(scorer.Name(), comment.Username, scorer.ScoreComment(comment))
+	}
+}
+
+// addScorerPoints soma pontos de um usuário em um Scorer
+func (pc *PRChampion) addScorerPoints(scorerName, username string, points float64) {
+	if points == 0 {
+		return
+	}
+	if pc.scorerTotals == nil {
+		pc.scorerTotals = make(map[string]map[string]float64)
+	}
+	if pc.scorerTotals[scorerName] == nil {
+		pc.scorerTotals[scorerName] = make(map[string]float64)
+	}
+	pc.scorerTotals[scorerName][username] += points
+}
+
+// scorerTotalsFor retorna a pontuação de cada usuário em um Scorer
+func (pc *PRChampion) scorerTotalsFor(scorer Scorer) map[string]float64 {
+	statsScorer, ok := scorer.(UserStatsScorer)
+	if !ok {
+		return pc.scorerTotals[scorer.Name()]
+	}
+
+	totals := make(map[string]float64, len(pc.userStats))
+	for username, stats := range pc.userStats {
+		totals[username] = statsScorer.ScoreUser(stats)
+	}
+	return totals
+}
+
+// statsFor retorna as estatísticas do usuário (vazias para quem não tem PRs nem comentários)
+func (pc *PRChampion) statsFor(username string) *UserStats {
+	if stats := pc.userStats[username]; stats != nil {
+		return stats
+	}
+	return &UserStats{Username: username}
+}
+
+// Leaderboard retorna o ranking de um Scorer, ordenado pela pontuação,
+// depois pelo critério de desempate do Scorer e, por fim, pelo nome do usuário
+func (pc *PRChampion) Leaderboard(scorer Scorer, limit int) []LeaderboardEntry {
+	totals := pc.scorerTotalsFor(scorer)
+
+	var entries []LeaderboardEntry
+	for username, score := range totals {
+		if score > 0 { // Apenas usuários com pontuação positiva entram no ranking
+			entries = append(entries, LeaderboardEntry{Username: username, Score: score})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		a, b := pc.statsFor(entries[i].Username), pc.statsFor(entries[j].Username)
+		if scorer.TieBreak(a, b) {
+			return true
+		}
+		if scorer.TieBreak(b, a) {
+			return false
+		}
+		return entries[i].Username < entries[j].Username
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
+
+// formatScore formata uma pontuação sem casas decimais quando ela é inteira
+func formatScore(score float64) string {
+	if score == float64(int64(score)) {
+		return fmt.Sprintf("%d", int64(score))
+	}
+	return fmt.Sprintf("%.2f", score)
+}
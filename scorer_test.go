@@ -0,0 +1,152 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// reviewOnlyScorer é um Scorer de teste que pontua apenas review comments
+type reviewOnlyScorer struct{}
+
+func (reviewOnlyScorer) Name() string                           { return "test-reviews" }
+func (reviewOnlyScorer) Title() string                          { return "TOP REVIEWS" }
+func (reviewOnlyScorer) Unit() string                           { return "reviews" }
+func (reviewOnlyScorer) ScorePR(pr *github.PullRequest) float64 { return 0 }
+func (reviewOnlyScorer) TieBreak(a, b *UserStats) bool          { return false }
+func (reviewOnlyScorer) ScoreComment(comment ScoredComment) float64 {
+	if comment.CommentType == "review" {
+		return 1
+	}
+	return 0
+}
+
+func TestLookupScorers(t *testing.T) {
+	scorers, err := LookupScorers([]string{"prs", " Weighted-Comments ", ""})
+	if err != nil {
+		t.Fatalf("LookupScorers() error = %v", err)
+	}
+	if len(scorers) != 2 || scorers[0].Name() != "prs" || scorers[1].Name() != "weighted-comments" {
+		t.Errorf("Unexpected scorers: %v", scorers)
+	}
+
+	if _, err := LookupScorers([]string{"unknown"}); err == nil {
+		t.Error("Expected error for unknown scorer")
+	}
+}
+
+func TestLeaderboardWithBuiltinScorers(t *testing.T) {
+	monday, _ := time.Parse("2006-01-02 15:04:05", "2024-09-30 10:00:00")
+
+	pc := &PRChampion{userStats: make(map[string]*UserStats)}
+	pc.SetScorers([]Scorer{prCountScorer{}, weightedCommentScorer{}})
+
+	pc.processWeeklyData([]*github.PullRequest{
+		newTestPR("org", "api", "alice", 1, monday),
+		newTestPR("org", "api", "bob", 2, monday),
+		newTestPR("org", "api", "carol", 3, monday.Add(7*24*time.Hour)),
+		newTestPR("org", "api", "carol", 4, monday.Add(7*24*time.Hour)),
+	})
+	weekKey := pc.weeklyData[0].StartDate.Format("2006-01-02")
+	pc.processWeeklyComments(
+		map[string]map[string]int{weekKey: {"alice": 1, "bob": 1}},
+		map[string]map[string]float64{weekKey: {"alice": 3, "bob": -1}},
+		map[string]map[string]map[string]int{},
+		map[string]map[string]map[string]float64{},
+		map[string]time.Time{weekKey: pc.weeklyData[0].StartDate},
+	)
+	pc.calculateUserStats()
+
+	prs := pc.Leaderboard(prCountScorer{}, 5)
+	if len(prs) != 3 || prs[0].Username != "carol" || prs[0].Score != 2 {
+		t.Fatalf("Unexpected PR leaderboard: %v", prs)
+	}
+	// alice e bob empatam; o vencedor da semana fica à frente pelo critério de desempate
+	winner := pc.weeklyData[0].Winner
+	if prs[1].Username != winner {
+		t.Errorf("Expected weekly winner %s in second place, got %v", winner, prs)
+	}
+
+	weighted := pc.Leaderboard(weightedCommentScorer{}, 5)
+	if len(weighted) != 1 || weighted[0].Username != "alice" || weighted[0].Score != 3 {
+		t.Errorf("Expected only alice with positive weighted score, got %v", weighted)
+	}
+}
+
+// registerTestScorer registra um Scorer e o remove do registro global ao fim do teste
+func registerTestScorer(t *testing.T, scorer Scorer) {
+	t.Helper()
+
+	name := scorer.Name()
+	if _, exists := scorerRegistry[name]; exists {
+		t.Fatalf("Scorer %q is already registered", name)
+	}
+	RegisterScorer(scorer)
+
+	t.Cleanup(func() {
+		delete(scorerRegistry, name)
+		for i, registered := range scorerOrder {
+			if registered == name {
+				scorerOrder = append(scorerOrder[:i:i], scorerOrder[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+func TestCustomScorer(t *testing.T) {
+	registerTestScorer(t, reviewOnlyScorer{})
+
+	scorers, err := LookupScorers([]string{"test-reviews"})
+	if err != nil {
+		t.Fatalf("LookupScorers() error = %v", err)
+	}
+
+	pc := &PRChampion{userStats: make(map[string]*UserStats)}
+	pc.SetScorers(scorers)
+	pc.addScorerComment(ScoredComment{Username: "alice", CommentType: "issue"})
+	pc.addScorerComment(ScoredComment{Username: "bob", CommentType: "review"})
+	pc.addScorerComment(ScoredComment{Username: "bob", CommentType: "review"})
+
+	entries := pc.Leaderboard(scorers[0], 5)
+	if len(entries) != 1 || entries[0].Username != "bob" || entries[0].Score != 2 {
+		t.Errorf("Unexpected custom leaderboard: %v", entries)
+	}
+}
+
+func TestReportSectionsFollowSelectedScorers(t *testing.T) {
+	pc := &PRChampion{
+		userStats: map[string]*UserStats{
+			"alice": {Username: "alice", TotalScore: 2, WeeklyWins: 2, PRsCount: 5, WeightedCommentWeeklyScore: 1},
+		},
+	}
+
+	render := func() (string, string) {
+		var text, markdown strings.Builder
+		pc.WriteTextReport(&text)
+		if err := pc.WriteMarkdownReport(&markdown); err != nil {
+			t.Fatalf("WriteMarkdownReport() error = %v", err)
+		}
+		return text.String(), markdown.String()
+	}
+
+	// Os rankings detalhados fazem parte do padrão
+	text, markdown := render()
+	if !strings.Contains(text, "🏅 RANKING GERAL POR PONTUAÇÃO:") || !strings.Contains(text, "   🏆 Vitórias semanais: 2") {
+		t.Errorf("Expected the default general ranking in the text report, got:\n%s", text)
+	}
+	if !strings.Contains(markdown, "| Posição | Usuário | Pontuação | Vitórias semanais | Total de PRs |") {
+		t.Errorf("Expected the default general ranking in the Markdown report, got:\n%s", markdown)
+	}
+
+	// --scorer prs remove os demais rankings
+	pc.SetScorers([]Scorer{prCountScorer{}})
+	text, markdown = render()
+	for _, report := range []string{text, markdown} {
+		if strings.Contains(report, "RANKING GERAL") || strings.Contains(report, "QUALIDADE DOS COMENTÁRIOS") {
+			t.Errorf("Expected only the selected scorer, got:\n%s", report)
+		}
+	}
+}
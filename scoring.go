@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScoringConfig define os pesos usados no cálculo das pontuações
+type ScoringConfig struct {
+	BaseCommentScore float64            `json:"base_comment_score" yaml:"base_comment_score"` // Pontuação base de cada comentário
+	ReactionWeights  map[string]float64 `json:"reaction_weights" yaml:"reaction_weights"`     // Peso por tipo de reação ("+1", "-1", "heart", ...)
+	MinCommentScore  *float64           `json:"min_comment_score" yaml:"min_comment_score"`   // Piso da pontuação de um comentário (nil = sem piso)
+	MaxCommentScore  *float64           `json:"max_comment_score" yaml:"max_comment_score"`   // Teto da pontuação de um comentário (nil = sem teto)
+	WeeklyPoints     WeeklyPointsConfig `json:"weekly_points" yaml:"weekly_points"`           // Pontos concedidos por cada título semanal
+}
+
+// WeeklyPointsConfig define quantos pontos cada título semanal vale
+type WeeklyPointsConfig struct {
+	PRChampion      int `json:"pr_champion" yaml:"pr_champion"`           // Campeão por PRs mergeados
+	CommentChampion int `json:"comment_champion" yaml:"comment_champion"` // Campeão por número de comentários
+	QualityChampion int `json:"quality_champion" yaml:"quality_champion"` // Campeão por qualidade dos comentários
+}
+
+// DefaultScoringConfig retorna a configuração de pontuação padrão
+func DefaultScoringConfig() *ScoringConfig {
+	minScore := -1.0 // Comentários muito mal recebidos valem no mínimo -1
+
+	return &ScoringConfig{
+		BaseCommentScore: 1.0,
+		ReactionWeights: map[string]float64{
+			"+1":       2.0,  // 👍
+			"-1":       -2.0, // 👎 neutraliza o ponto base e ainda penaliza
+			"heart":    0.5,  // ❤️
+			"hooray":   0.5,  // 🎉
+			"rocket":   0.5,  // 🚀
+			"confused": -0.5, // 😕
+			"eyes":     -0.5, // 👀
+		},
+		MinCommentScore: &minScore,
+		WeeklyPoints: WeeklyPointsConfig{
+			PRChampion:      1,
+			CommentChampion: 1,
+			QualityChampion: 1,
+		},
+	}
+}
+
+// LoadScoringConfig carrega uma configuração de pontuação em YAML ou JSON.
+// Os valores do arquivo sobrescrevem os padrões; campos omitidos mantêm o valor padrão.
+func LoadScoringConfig(path string) (*ScoringConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler configuração de pontuação: %v", err)
+	}
+
+	cfg := DefaultScoringConfig()
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("erro ao interpretar configuração de pontuação JSON: %v", err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("erro ao interpretar configuração de pontuação YAML: %v", err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate verifica se a configuração de pontuação é consistente
+func (cfg *ScoringConfig) Validate() error {
+	if cfg.MinCommentScore != nil && cfg.MaxCommentScore != nil && *cfg.MinCommentScore > *cfg.MaxCommentScore {
+		return fmt.Errorf("configuração de pontuação inválida: min_comment_score (%.2f) maior que max_comment_score (%.2f)",
+			*cfg.MinCommentScore, *cfg.MaxCommentScore)
+	}
+
+	points := cfg.WeeklyPoints
+	if points.PRChampion < 0 || points.CommentChampion < 0 || points.QualityChampion < 0 {
+		return fmt.Errorf("configuração de pontuação inválida: pontos semanais não podem ser negativos")
+	}
+
+	return nil
+}
+
+// ReactionWeight retorna o peso de uma reação (0 para reações não mapeadas)
+func (cfg *ScoringConfig) ReactionWeight(content string) float64 {
+	return cfg.ReactionWeights[content]
+}
+
+// ClampCommentScore aplica o piso e o teto configurados à pontuação de um comentário
+func (cfg *ScoringConfig) ClampCommentScore(score float64) float64 {
+	if cfg.MinCommentScore != nil && score < *cfg.MinCommentScore {
+		score = *cfg.MinCommentScore
+	}
+	if cfg.MaxCommentScore != nil && score > *cfg.MaxCommentScore {
+		score = *cfg.MaxCommentScore
+	}
+	return score
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+func TestLoadScoringConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "scoring.yaml")
+	yamlContent := `
+base_comment_score: 2
+reaction_weights:
+  "+1": 3
+  laugh: 1
+max_comment_score: 6
+weekly_points:
+  pr_champion: 3
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadScoringConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadScoringConfig() error = %v", err)
+	}
+
+	if cfg.BaseCommentScore != 2 || cfg.ReactionWeight("+1") != 3 || cfg.ReactionWeight("laugh") != 1 {
+		t.Errorf("Unexpected overridden values: %+v", cfg)
+	}
+	// Valores omitidos mantêm o padrão
+	if cfg.ReactionWeight("heart") != 0.5 || cfg.MinCommentScore == nil || *cfg.MinCommentScore != -1 {
+		t.Errorf("Expected defaults to be kept, got %+v", cfg)
+	}
+	if cfg.WeeklyPoints.PRChampion != 3 || cfg.WeeklyPoints.QualityChampion != 1 {
+		t.Errorf("Unexpected weekly points: %+v", cfg.WeeklyPoints)
+	}
+
+	jsonPath := filepath.Join(dir, "scoring.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"weekly_points": {"quality_champion": 2}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadScoringConfig(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadScoringConfig() error = %v", err)
+	}
+	if cfg.WeeklyPoints.QualityChampion != 2 || cfg.WeeklyPoints.PRChampion != 1 {
+		t.Errorf("Unexpected weekly points from JSON: %+v", cfg.WeeklyPoints)
+	}
+
+	// null remove o piso padrão
+	noFloorPath := filepath.Join(dir, "no-floor.yaml")
+	if err := os.WriteFile(noFloorPath, []byte("min_comment_score: null\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadScoringConfig(noFloorPath)
+	if err != nil {
+		t.Fatalf("LoadScoringConfig() error = %v", err)
+	}
+	if cfg.MinCommentScore != nil || cfg.ClampCommentScore(-5) != -5 {
+		t.Errorf("Expected no floor with min_comment_score: null, got %v", cfg.MinCommentScore)
+	}
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidPath, []byte("min_comment_score: 5\nmax_comment_score: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScoringConfig(invalidPath); err == nil {
+		t.Error("Expected error for min_comment_score greater than max_comment_score")
+	}
+
+	unknownPath := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(unknownPath, []byte("base_score: 5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScoringConfig(unknownPath); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
+
+func TestCustomScoringConfig(t *testing.T) {
+	maxScore := 4.0
+	cfg := DefaultScoringConfig()
+	cfg.ReactionWeights["laugh"] = 1.0
+	cfg.MaxCommentScore = &maxScore
+	cfg.WeeklyPoints = WeeklyPointsConfig{PRChampion: 3, CommentChampion: 2, QualityChampion: 5}
+
+	pc := &PRChampion{userStats: make(map[string]*UserStats)}
+	pc.SetScoringConfig(cfg)
+
+	mergedAt := time.Now()
+	reactions := []*github.Reaction{
+		{Content: github.String("laugh"), CreatedAt: &github.Timestamp{Time: mergedAt.Add(-time.Hour)}},
+	}
+	if score := pc.calculateScoreFromReactions(reactions, mergedAt); score != 2.0 {
+		t.Errorf("Expected score 2.0 with custom laugh weight, got %.1f", score)
+	}
+
+	reactions = []*github.Reaction{
+		{Content: github.String("+1"), CreatedAt: &github.Timestamp{Time: mergedAt.Add(-time.Hour)}},
+		{Content: github.String("+1"), CreatedAt: &github.Timestamp{Time: mergedAt.Add(-time.Hour)}},
+	}
+	if score := pc.calculateScoreFromReactions(reactions, mergedAt); score != 4.0 {
+		t.Errorf("Expected score capped at 4.0, got %.1f", score)
+	}
+
+	pc.weeklyData = []WeeklyData{
+		{
+			UserPRs:               map[string]int{"user1": 2},
+			Winner:                "user1",
+			UserComments:          map[string]int{"user1": 3},
+			CommentWinner:         "user1",
+			UserWeightedComments:  map[string]float64{"user1": 3},
+			WeightedCommentWinner: "user1",
+		},
+	}
+	pc.calculateUserStats()
+
+	user1 := pc.userStats["user1"]
+	if user1.TotalScore != 3 || user1.CommentScore != 2 || user1.WeightedCommentWeeklyScore != 5 {
+		t.Errorf("Unexpected points with custom weekly points: %+v", user1)
+	}
+	if user1.WeeklyWins != 1 || user1.CommentWeeklyWins != 1 || user1.WeightedCommentWeeklyWins != 1 {
+		t.Errorf("Expected wins to still count once: %+v", user1)
+	}
+}
+
+// failingReactionsClient falha ao buscar reações de qualquer comentário
+type failingReactionsClient struct {
+	fakeCommentsClient
+}
+
+func (f *failingReactionsClient) ListIssueCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return nil, fmt.Errorf("falha simulada")
+}
+
+func (f *failingReactionsClient) ListPullRequestCommentReactions(ctx context.Context, owner, repo string, commentID int64) ([]*github.Reaction, error) {
+	return nil, fmt.Errorf("falha simulada")
+}
+
+func TestCommentScoreFallbackUsesBaseScore(t *testing.T) {
+	pc := &PRChampion{client: &failingReactionsClient{}}
+	cfg := DefaultScoringConfig()
+	cfg.BaseCommentScore = 3
+	pc.SetScoringConfig(cfg)
+
+	ctx := context.Background()
+	mergedAt := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	if score, err := pc.calculateCommentScore(ctx, "org", "api", 1, mergedAt); err == nil || score != 3 {
+		t.Errorf("Expected base score 3 with an error for issue comments, got %.1f (err = %v)", score, err)
+	}
+	if score, err := pc.calculateReviewCommentScore(ctx, "org", "api", 2, mergedAt); err == nil || score != 3 {
+		t.Errorf("Expected base score 3 with an error for review comments, got %.1f (err = %v)", score, err)
+	}
+}
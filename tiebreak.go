@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TiePolicy define como os empates dos títulos semanais são resolvidos
+type TiePolicy string
+
+const (
+	// TiePolicyShared faz todos os usuários empatados dividirem o título (todos ganham o ponto)
+	TiePolicyShared TiePolicy = "shared"
+	// TiePolicySecondary desempata por métricas secundárias (pontuação ponderada, depois merge mais cedo)
+	TiePolicySecondary TiePolicy = "secondary"
+	// TiePolicyAlphabetical desempata pela ordem alfabética do usuário
+	TiePolicyAlphabetical TiePolicy = "alphabetical"
+)
+
+// DefaultTiePolicy é a política usada quando nenhuma é informada
+const DefaultTiePolicy = TiePolicySecondary
+
+// ParseTiePolicy valida e normaliza a política de desempate informada
+func ParseTiePolicy(value string) (TiePolicy, error) {
+	policy := TiePolicy(strings.ToLower(strings.TrimSpace(value)))
+	switch policy {
+	case "":
+		return DefaultTiePolicy, nil
+	case TiePolicyShared, TiePolicySecondary, TiePolicyAlphabetical:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("política de desempate inválida: %s (use shared, secondary ou alphabetical)", value)
+	}
+}
+
+// tieComparator compara dois usuários empatados; retorna negativo se a vence, positivo se b vence e 0 se continuam empatados
+type tieComparator func(a, b string) int
+
+// pickWinners retorna os vencedores de uma métrica aplicando a política de desempate.
+// Apenas pontuações positivas concorrem ao título. Os comparadores secundários são usados,
+// em ordem, somente pela política "secondary"; a ordem alfabética é sempre o critério final.
+func pickWinners(scores map[string]float64, policy TiePolicy, secondary ...tieComparator) []string {
+	ranked := rankUsers(scores, policy, secondary...)
+	if len(ranked) == 0 || scores[ranked[0]] <= 0 {
+		return nil
+	}
+
+	if policy != TiePolicyShared {
+		return ranked[:1]
+	}
+
+	// Na política "shared", todos os líderes dividem o título (já em ordem alfabética)
+	leaders := ranked[:1]
+	for _, user := range ranked[1:] {
+		if scores[user] != scores[ranked[0]] {
+			break
+		}
+		leaders = append(leaders, user)
+	}
+	return leaders
+}
+
+// rankUsers ordena os usuários pela pontuação, desempatando como pickWinners: pelos comparadores
+// secundários na política "secondary" e, por fim, pela ordem alfabética
+func rankUsers(scores map[string]float64, policy TiePolicy, secondary ...tieComparator) []string {
+	users := make([]string, 0, len(scores))
+	for user := range scores {
+		users = append(users, user)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		a, b := users[i], users[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		if policy == TiePolicySecondary {
+			for _, compare := range secondary {
+				if result := compare(a, b); result != 0 {
+					return result < 0
+				}
+			}
+		}
+		return a < b
+	})
+
+	return users
+}
+
+// compareFloatDesc cria um comparador que favorece o maior valor do mapa
+func compareFloatDesc(values map[string]float64) tieComparator {
+	return func(a, b string) int {
+		switch {
+		case values[a] > values[b]:
+			return -1
+		case values[a] < values[b]:
+			return 1
+		}
+		return 0
+	}
+}
+
+// compareIntDesc cria um comparador que favorece o maior valor do mapa
+func compareIntDesc(values map[string]int) tieComparator {
+	return func(a, b string) int {
+		return values[b] - values[a]
+	}
+}
+
+// compareTimeAsc cria um comparador que favorece o instante mais cedo (usuários sem registro perdem)
+func compareTimeAsc(values map[string]time.Time) tieComparator {
+	return func(a, b string) int {
+		ta, okA := values[a]
+		tb, okB := values[b]
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		case !okA && !okB:
+			return 0
+		case ta.Before(tb):
+			return -1
+		case tb.Before(ta):
+			return 1
+		}
+		return 0
+	}
+}
+
+// intScores converte um mapa de contagens para pontuações
+func intScores(values map[string]int) map[string]float64 {
+	scores := make(map[string]float64, len(values))
+	for user, value := range values {
+		scores[user] = float64(value)
+	}
+	return scores
+}
+
+// firstOrEmpty retorna o primeiro vencedor ou string vazia
+func firstOrEmpty(winners []string) string {
+	if len(winners) == 0 {
+		return ""
+	}
+	return winners[0]
+}
+
+// SetTiePolicy define a política de desempate dos títulos semanais
+func (pc *PRChampion) SetTiePolicy(policy TiePolicy) {
+	pc.tiePolicy = policy
+}
+
+// activeTiePolicy retorna a política de desempate em uso
+func (pc *PRChampion) activeTiePolicy() TiePolicy {
+	if pc.tiePolicy == "" {
+		return DefaultTiePolicy
+	}
+	return pc.tiePolicy
+}
+
+// resolveWeeklyWinners define os vencedores de cada semana e de cada repositório aplicando a política de desempate
+func (pc *PRChampion) resolveWeeklyWinners() {
+	policy := pc.activeTiePolicy()
+
+	for i := range pc.weeklyData {
+		week := &pc.weeklyData[i]
+		week.TiePolicy = string(policy)
+
+		week.Winners = pickWinners(intScores(week.UserPRs), policy, week.prTieBreakers()...)
+		week.Winner = firstOrEmpty(week.Winners)
+
+		// Campeão por comentários: desempata pela pontuação ponderada
+		week.CommentWinners = pickWinners(intScores(week.UserComments), policy,
+			compareFloatDesc(week.UserWeightedComments))
+		week.CommentWinner = firstOrEmpty(week.CommentWinners)
+
+		week.WeightedCommentWinners = pickWinners(week.UserWeightedComments, policy, week.weightedCommentTieBreakers()...)
+		week.WeightedCommentWinner = firstOrEmpty(week.WeightedCommentWinners)
+
+		// Campeões por repositório
+		if len(week.RepoData) > 0 {
+			week.RepoWinners = make(map[string][]string)
+			for repo, repoUserPRs := range week.RepoData {
+				winners := pickWinners(intScores(repoUserPRs), policy, compareTimeAsc(week.FirstMergeAt))
+				if len(winners) > 0 {
+					week.RepoWinners[repo] = winners
+				}
+			}
+		}
+	}
+}
+
+// prTieBreakers desempata o título por PRs pela pontuação ponderada da semana e depois pelo merge mais cedo
+func (week WeeklyData) prTieBreakers() []tieComparator {
+	return []tieComparator{compareFloatDesc(week.UserWeightedComments), compareTimeAsc(week.FirstMergeAt)}
+}
+
+// weightedCommentTieBreakers desempata o título por qualidade pelo número de comentários
+func (week WeeklyData) weightedCommentTieBreakers() []tieComparator {
+	return []tieComparator{compareIntDesc(week.UserComments)}
+}
+
+// weekTiePolicy retorna a política aplicada à semana (ou a política em uso, se a semana ainda não foi resolvida)
+func (pc *PRChampion) weekTiePolicy(week WeeklyData) TiePolicy {
+	if week.TiePolicy != "" {
+		return TiePolicy(week.TiePolicy)
+	}
+	return pc.activeTiePolicy()
+}
+
+// prWinners retorna os campeões por PRs da semana
+func (week WeeklyData) prWinners() []string {
+	if len(week.Winners) > 0 {
+		return week.Winners
+	}
+	if week.Winner != "" {
+		return []string{week.Winner}
+	}
+	return nil
+}
+
+// commentWinners retorna os campeões por comentários da semana
+func (week WeeklyData) commentWinners() []string {
+	if len(week.CommentWinners) > 0 {
+		return week.CommentWinners
+	}
+	if week.CommentWinner != "" {
+		return []string{week.CommentWinner}
+	}
+	return nil
+}
+
+// weightedCommentWinners retorna os campeões por qualidade de comentários da semana
+func (week WeeklyData) weightedCommentWinners() []string {
+	if len(week.WeightedCommentWinners) > 0 {
+		return week.WeightedCommentWinners
+	}
+	if week.WeightedCommentWinner != "" {
+		return []string{week.WeightedCommentWinner}
+	}
+	return nil
+}
+
+// containsUser verifica se o usuário está na lista
+func containsUser(users []string, username string) bool {
+	for _, user := range users {
+		if user == username {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// newTiedChampion cria um PRChampion em que bob e alice empatam em PRs na mesma semana,
+// com bob mergeando primeiro
+func newTiedChampion(policy TiePolicy) *PRChampion {
+	monday, _ := time.Parse("2006-01-02 15:04:05", "2024-09-30 10:00:00")
+
+	pc := &PRChampion{
+		userStats:    make(map[string]*UserStats),
+		repositories: []Repository{{Owner: "org", Name: "api"}},
+	}
+	pc.SetTiePolicy(policy)
+
+	prs := []*github.PullRequest{
+		newTestPR("org", "api", "bob", 1, monday),
+		newTestPR("org", "api", "alice", 2, monday.Add(24*time.Hour)),
+		newTestPR("org", "api", "bob", 3, monday.Add(48*time.Hour)),
+		newTestPR("org", "api", "alice", 4, monday.Add(72*time.Hour)),
+	}
+	pc.processWeeklyData(prs)
+	return pc
+}
+
+func TestParseTiePolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TiePolicy
+		wantErr  bool
+	}{
+		{"", DefaultTiePolicy, false},
+		{"shared", TiePolicyShared, false},
+		{" Secondary ", TiePolicySecondary, false},
+		{"alphabetical", TiePolicyAlphabetical, false},
+		{"random", "", true},
+	}
+
+	for _, tt := range tests {
+		policy, err := ParseTiePolicy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTiePolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if policy != tt.expected {
+			t.Errorf("ParseTiePolicy(%q) = %q, want %q", tt.input, policy, tt.expected)
+		}
+	}
+}
+
+func TestTiePolicySharedGivesPointToAllTiedUsers(t *testing.T) {
+	pc := newTiedChampion(TiePolicyShared)
+	pc.calculateUserStats()
+
+	week := pc.weeklyData[0]
+	if !reflect.DeepEqual(week.Winners, []string{"alice", "bob"}) {
+		t.Errorf("Winners = %v, want [alice bob]", week.Winners)
+	}
+	if week.TiePolicy != string(TiePolicyShared) {
+		t.Errorf("TiePolicy = %q, want %q", week.TiePolicy, TiePolicyShared)
+	}
+	if pc.userStats["alice"].WeeklyWins != 1 || pc.userStats["bob"].WeeklyWins != 1 {
+		t.Errorf("Expected both tied users to get the weekly win, got alice=%d bob=%d",
+			pc.userStats["alice"].WeeklyWins, pc.userStats["bob"].WeeklyWins)
+	}
+	if !reflect.DeepEqual(week.RepoWinners["org/api"], []string{"alice", "bob"}) {
+		t.Errorf("RepoWinners[org/api] = %v, want [alice bob]", week.RepoWinners["org/api"])
+	}
+
+	// O JSON lista todos os empatados, inclusive por repositório
+	jsonWeek := pc.BuildJSONReport().Weeks[0]
+	if !reflect.DeepEqual(jsonWeek.Winners, []string{"alice", "bob"}) || !reflect.DeepEqual(jsonWeek.RepoWinners["org/api"], []string{"alice", "bob"}) {
+		t.Errorf("Expected all tied users in the JSON week, got winners %v and repo winners %v", jsonWeek.Winners, jsonWeek.RepoWinners)
+	}
+}
+
+func TestTiePolicySecondaryUsesEarliestMerge(t *testing.T) {
+	pc := newTiedChampion(TiePolicySecondary)
+
+	week := pc.weeklyData[0]
+	if week.Winner != "bob" || !reflect.DeepEqual(week.Winners, []string{"bob"}) {
+		t.Errorf("Winner = %q (%v), want bob (earliest merge)", week.Winner, week.Winners)
+	}
+}
+
+func TestTiePolicySecondaryPrefersWeightedScore(t *testing.T) {
+	pc := newTiedChampion(TiePolicySecondary)
+
+	// Alice tem a melhor pontuação ponderada na semana, o que vale antes do merge mais cedo
+	weekKey := pc.weeklyData[0].StartDate.Format("2006-01-02")
+	weekStarts := map[string]time.Time{weekKey: pc.weeklyData[0].StartDate}
+	pc.processWeeklyComments(
+		map[string]map[string]int{weekKey: {"alice": 2, "bob": 2}},
+		map[string]map[string]float64{weekKey: {"alice": 5, "bob": 2}},
+		map[string]map[string]map[string]int{},
+		map[string]map[string]map[string]float64{},
+		weekStarts,
+	)
+
+	week := pc.weeklyData[0]
+	if week.Winner != "alice" {
+		t.Errorf("Winner = %q, want alice (higher weighted score)", week.Winner)
+	}
+	if week.CommentWinner != "alice" {
+		t.Errorf("CommentWinner = %q, want alice (higher weighted score)", week.CommentWinner)
+	}
+}
+
+func TestTiePolicyAlphabetical(t *testing.T) {
+	pc := newTiedChampion(TiePolicyAlphabetical)
+
+	week := pc.weeklyData[0]
+	if week.Winner != "alice" || week.TiePolicy != string(TiePolicyAlphabetical) {
+		t.Errorf("Winner = %q with policy %q, want alice with alphabetical", week.Winner, week.TiePolicy)
+	}
+}
+
+func TestPickWinnersIsDeterministic(t *testing.T) {
+	scores := map[string]float64{"carol": 3, "alice": 3, "bob": 3, "dave": 1}
+
+	for i := 0; i < 20; i++ {
+		if winners := pickWinners(scores, TiePolicySecondary); !reflect.DeepEqual(winners, []string{"alice"}) {
+			t.Fatalf("pickWinners() = %v, want [alice]", winners)
+		}
+	}
+
+	if winners := pickWinners(map[string]float64{"alice": 0}, TiePolicyShared); winners != nil {
+		t.Errorf("pickWinners() with no positive score = %v, want nil", winners)
+	}
+}
+
+func TestWeeklyPodiumFollowsTiePolicy(t *testing.T) {
+	for policy, expected := range map[TiePolicy][]string{
+		TiePolicySecondary:    {"bob", "alice"}, // bob mergeou primeiro, como no título
+		TiePolicyAlphabetical: {"alice", "bob"},
+		TiePolicyShared:       {"alice", "bob"},
+	} {
+		pc := newTiedChampion(policy)
+		week := pc.weeklyData[0]
+
+		for i := 0; i < 20; i++ {
+			var podium []string
+			for _, user := range pc.getTopUsersForWeek(week, 3) {
+				podium = append(podium, user.Username)
+			}
+			if !reflect.DeepEqual(podium, expected) {
+				t.Fatalf("Podium with policy %s = %v, want %v", policy, podium, expected)
+			}
+		}
+		if podium := pc.getTopUsersForWeek(week, 3); podium[0].Username != week.prWinners()[0] {
+			t.Errorf("Expected the podium to start with the champion %s, got %s", week.prWinners()[0], podium[0].Username)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// usageResourceLabels são os nomes exibidos no relatório para cada recurso do cache
+var usageResourceLabels = map[string]string{
+	infrastructure.ResourcePRs:            "PRs",
+	infrastructure.ResourceIssueComments:  "Issue comments",
+	infrastructure.ResourceReviewComments: "Review comments",
+	infrastructure.ResourceReactions:      "Reações",
+}
+
+// usageStats retorna o uso do cache e da API desta execução, quando o cliente o contabiliza
+func (pc *PRChampion) usageStats() (infrastructure.UsageStats, bool) {
+	reporter, ok := pc.client.(infrastructure.UsageReporter)
+	if !ok {
+		return infrastructure.UsageStats{}, false
+	}
+	return reporter.Usage(), true
+}
+
+// writeUsageSection escreve os hits e misses do cache, as chamadas à API e o tempo da busca
+func (pc *PRChampion) writeUsageSection(w io.Writer) {
+	usage, ok := pc.usageStats()
+	if !ok {
+		return
+	}
+
+	fmt.Fprintln(w, "📊 Uso nesta execução:")
+	for _, resource := range infrastructure.UsageResources {
+		counter := usage.Cache[resource]
+		fmt.Fprintf(w, "   • %s: %d hits, %d misses (%.0f%% do cache)\n",
+			usageResourceLabels[resource], counter.Hits, counter.Misses, counter.HitRate()*100)
+	}
+	fmt.Fprintf(w, "🌐 Chamadas à API: %d (%s)\n", usage.APICalls, usage.APITime.Round(time.Millisecond))
+	if usage.RateLimit.Known {
+		fmt.Fprintf(w, "🚦 Rate limit restante: %d/%d\n", usage.RateLimit.Remaining, usage.RateLimit.Limit)
+	}
+	if pc.fetchTime > 0 {
+		fmt.Fprintf(w, "⏱️  Tempo total da busca: %s\n", pc.fetchTime.Round(time.Millisecond))
+	}
+}
+
+// buildJSONUsage monta a seção de uso do relatório JSON (nil quando o cliente não contabiliza o uso)
+func (pc *PRChampion) buildJSONUsage() *JSONUsage {
+	usage, ok := pc.usageStats()
+	if !ok {
+		return nil
+	}
+
+	report := &JSONUsage{
+		Cache:           []JSONCacheUsage{},
+		APICalls:        usage.APICalls,
+		APISeconds:      usage.APITime.Seconds(),
+		DurationSeconds: pc.fetchTime.Seconds(),
+	}
+	for _, resource := range infrastructure.UsageResources {
+		counter := usage.Cache[resource]
+		report.Cache = append(report.Cache, JSONCacheUsage{
+			Resource: resource,
+			Hits:     counter.Hits,
+			Misses:   counter.Misses,
+			HitRate:  counter.HitRate(),
+		})
+	}
+	if usage.RateLimit.Known {
+		report.RateLimit = &JSONRateLimit{
+			Limit:     usage.RateLimit.Limit,
+			Remaining: usage.RateLimit.Remaining,
+			Reset:     usage.RateLimit.Reset,
+		}
+	}
+	return report
+}
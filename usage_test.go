@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrcorrea/PRPG/internal/infrastructure"
+)
+
+// fakeUsageClient informa estatísticas de uso fixas
+type fakeUsageClient struct {
+	fakeCommentsClient
+	usage infrastructure.UsageStats
+}
+
+func (f *fakeUsageClient) Usage() infrastructure.UsageStats {
+	return f.usage
+}
+
+func TestReportsIncludeUsage(t *testing.T) {
+	pc := newReportTestChampion()
+
+	// Sem contabilização de uso no cliente, a seção é omitida
+	if report := pc.BuildJSONReport(); report.Usage != nil {
+		t.Errorf("Expected no usage without a usage reporter, got %+v", report.Usage)
+	}
+
+	pc.client = &fakeUsageClient{usage: infrastructure.UsageStats{
+		Cache: map[string]infrastructure.CacheCounter{
+			infrastructure.ResourcePRs:       {Hits: 1, Misses: 1},
+			infrastructure.ResourceReactions: {Hits: 3, Misses: 1},
+		},
+		APICalls:  2,
+		APITime:   1500 * time.Millisecond,
+		RateLimit: infrastructure.RateLimitStatus{Limit: 5000, Remaining: 4990, Known: true},
+	}}
+	pc.fetchTime = 3 * time.Second
+
+	var buf bytes.Buffer
+	if err := pc.WriteReport(ReportFormatJSON, &buf); err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	var report JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+
+	usage := report.Usage
+	if usage == nil || len(usage.Cache) != len(infrastructure.UsageResources) {
+		t.Fatalf("Expected usage for every resource, got %+v", usage)
+	}
+	if reactions := usage.Cache[3]; reactions.Resource != infrastructure.ResourceReactions || reactions.Hits != 3 || reactions.HitRate != 0.75 {
+		t.Errorf("Unexpected reactions usage: %+v", reactions)
+	}
+	if usage.APICalls != 2 || usage.APISeconds != 1.5 || usage.DurationSeconds != 3 {
+		t.Errorf("Unexpected API usage: %+v", usage)
+	}
+	if usage.RateLimit == nil || usage.RateLimit.Remaining != 4990 {
+		t.Errorf("Expected rate limit in usage, got %+v", usage.RateLimit)
+	}
+
+	buf.Reset()
+	pc.WriteTextReport(&buf)
+	for _, expected := range []string{"Reações: 3 hits, 1 misses (75% do cache)", "Chamadas à API: 2 (1.5s)", "Rate limit restante: 4990/5000", "Tempo total da busca: 3s"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected text report to contain %q", expected)
+		}
+	}
+}